@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lahiruramesh/dock-route/internal/docker"
+	"github.com/spf13/cobra"
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add [container-name] [package...]",
+	Short: "Add npm dependencies to a running project",
+	Long: `Install packages inside the project's running container using the project's
+package manager and print the resolved versions. The project directory is
+bind-mounted, so package.json and the lockfile change on the host directly.
+
+Examples:
+  dock-route add my-app axios
+  dock-route add my-app --dev @types/node vitest@2`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAdd,
+}
+
+var (
+	addDev     bool
+	addWorkDir string
+)
+
+// npmNamePattern matches valid (lowercase, optionally scoped) npm package
+// names. A leading - is rejected so names can't be read as options.
+var npmNamePattern = regexp.MustCompile(`^(@[a-z0-9~][a-z0-9-._~]*/)?[a-z0-9~][a-z0-9-._~]*$`)
+
+// deniedPackages are known malicious or typosquatting packages
+var deniedPackages = map[string]bool{
+	"crossenv":       true,
+	"cross-env.js":   true,
+	"d3.js":          true,
+	"fabric-js":      true,
+	"ffmepg":         true,
+	"flatmap-stream": true,
+	"gruntcli":       true,
+	"http-proxy.js":  true,
+	"jquery.js":      true,
+	"mongose":        true,
+	"mssql.js":       true,
+	"mssql-node":     true,
+	"mysqljs":        true,
+	"nodecaffe":      true,
+	"nodefabric":     true,
+	"node-fabric":    true,
+	"nodeffmpeg":     true,
+	"nodemailer-js":  true,
+	"nodemailer.js":  true,
+	"nodemssql":      true,
+	"node-opencv":    true,
+	"node-opensl":    true,
+	"node-openssl":   true,
+	"noderequest":    true,
+	"nodesass":       true,
+	"nodesqlite":     true,
+	"node-sqlite":    true,
+	"node-tkinter":   true,
+	"opencv.js":      true,
+	"openssl.js":     true,
+	"proxy.js":       true,
+	"shadowsock":     true,
+	"sqlite.js":      true,
+	"sqliter":        true,
+	"sqlserver":      true,
+	"tkinter":        true,
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+
+	addCmd.Flags().BoolVarP(&addDev, "dev", "D", false, "Add as development dependencies")
+	addCmd.Flags().StringVarP(&addWorkDir, "workdir", "w", "/app", "Project directory in container")
+}
+
+func runAdd(cmd *cobra.Command, args []string) error {
+	containerName := args[0]
+	packages := args[1:]
+
+	var names []string
+	for _, spec := range packages {
+		name, version := splitPackageSpec(spec)
+		if !npmNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid package name: %s", spec)
+		}
+		// Aliases (npm:), git, file, link and URL specs install something other than name
+		if strings.ContainsAny(version, ":/") {
+			return fmt.Errorf("only registry versions are allowed: %s", spec)
+		}
+		if deniedPackages[name] {
+			return fmt.Errorf("package %s is on the deny list", name)
+		}
+		names = append(names, name)
+	}
+
+	ctx := context.Background()
+
+	dockerClient, err := docker.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer dockerClient.Close()
+
+	projectPath, err := dockerClient.ProjectPath(ctx, containerName, addWorkDir)
+	if err != nil {
+		return err
+	}
+
+	command := installCommand(projectPath, packages, addDev)
+	log.Printf("Installing in container '%s': %s", containerName, strings.Join(command, " "))
+
+	exitCode, err := dockerClient.ExecuteCommand(ctx, containerName, command, addWorkDir, false)
+	if err != nil {
+		return fmt.Errorf("failed to execute command: %w", err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("install exited with code %d", exitCode)
+	}
+
+	for _, name := range names {
+		version, err := dockerClient.InstalledVersion(ctx, containerName, addWorkDir, name)
+		if err != nil {
+			log.Printf("⚠️  Could not resolve installed version of %s: %v", name, err)
+			continue
+		}
+		fmt.Printf("Added %s@%s\n", name, version)
+	}
+
+	return nil
+}
+
+// splitPackageSpec splits a spec like @scope/pkg@^1.2 into name and version.
+// The version is empty when the spec has none.
+func splitPackageSpec(spec string) (string, string) {
+	// Skip the leading @ of a scoped name
+	start := 0
+	if strings.HasPrefix(spec, "@") {
+		start = 1
+	}
+
+	if i := strings.Index(spec[start:], "@"); i >= 0 {
+		return spec[:start+i], spec[start+i+1:]
+	}
+	return spec, ""
+}
+
+// installCommand picks the package manager from the lockfile on the host
+func installCommand(projectPath string, packages []string, dev bool) []string {
+	var command []string
+	switch {
+	case fileExists(filepath.Join(projectPath, "pnpm-lock.yaml")):
+		command = []string{"pnpm", "add"}
+	case fileExists(filepath.Join(projectPath, "yarn.lock")):
+		command = []string{"yarn", "add"}
+	default:
+		command = []string{"npm", "install"}
+	}
+
+	if dev {
+		command = append(command, "-D")
+	}
+
+	// End option parsing so packages are never taken as flags
+	command = append(command, "--")
+	return append(command, packages...)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
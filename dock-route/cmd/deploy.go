@@ -2,14 +2,18 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/lahiruramesh/dock-route/internal/config"
 	"github.com/lahiruramesh/dock-route/internal/docker"
+	"github.com/lahiruramesh/dock-route/internal/fsutil"
 	"github.com/lahiruramesh/dock-route/internal/proxy"
 	"github.com/lahiruramesh/dock-route/internal/templates"
 	"github.com/spf13/cobra"
@@ -29,19 +33,29 @@ Example:
 }
 
 var (
-	imageName  string
-	hostPort   string
-	startProxy bool
-	devMode    bool // Add development mode flag
+	imageName    string
+	hostPort     string
+	startProxy   bool
+	devMode      bool // Add development mode flag
+	idleTTL      time.Duration
+	noBuild      bool
+	adminPort    string
+	staticMode   bool
+	readyTimeout time.Duration
 )
 
 func init() {
 	rootCmd.AddCommand(deployCmd)
 
 	deployCmd.Flags().StringVarP(&imageName, "image", "i", "", "Custom image name (default: auto-generated)")
-	deployCmd.Flags().StringVar(&hostPort, "host-port", "8081", "Host port to bind container port")
+	deployCmd.Flags().StringVar(&hostPort, "host-port", "", "Host port to bind container port (default: the container's previous port, else the first free one from 8081)")
 	deployCmd.Flags().BoolVar(&startProxy, "start-proxy", true, "Start the reverse proxy server")
 	deployCmd.Flags().BoolVar(&devMode, "dev", true, "Enable development mode with live editing") // Add this
+	deployCmd.Flags().BoolVar(&noBuild, "no-build", false, "Run the template's stock dev image with the source bind-mounted instead of building an image")
+	deployCmd.Flags().BoolVar(&staticMode, "static", false, "Run the production build and serve the exported files from the proxy instead of a container")
+	deployCmd.Flags().DurationVar(&readyTimeout, "ready-timeout", 2*time.Minute, "How long to wait for the app to answer HTTP requests after starting, 10m with --no-build (0 skips the check)")
+	deployCmd.Flags().StringVar(&adminPort, "admin-port", "", "Serve the proxy admin API on this localhost port so other deployments can register routes")
+	deployCmd.Flags().DurationVar(&idleTTL, "idle-ttl", 0, "Stop the container after this long without proxy requests and restart it on next access (0 disables)")
 }
 
 func runDeploy(cmd *cobra.Command, args []string) error {
@@ -49,6 +63,26 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	containerName := args[1]
 	sourcePath := args[2]
 
+	if noBuild && !devMode {
+		return fmt.Errorf("--no-build requires development mode")
+	}
+	if noBuild && imageName != "" {
+		return fmt.Errorf("--image cannot be combined with --no-build, which runs the template's dev image")
+	}
+	if staticMode && noBuild {
+		return fmt.Errorf("--static and --no-build cannot be combined")
+	}
+	if staticMode && viper.GetString("proxy_admin") != "" {
+		return fmt.Errorf("static sites can't be registered with a running proxy; deploy without --proxy-admin")
+	}
+	// These configure the proxy deploy starts, which a running proxy replaces
+	if idleTTL > 0 && viper.GetString("proxy_admin") != "" {
+		return fmt.Errorf("--idle-ttl can't be combined with --proxy-admin; start the proxy with 'dock-route proxy serve --idle-ttl' instead")
+	}
+	if adminPort != "" && viper.GetString("proxy_admin") != "" {
+		return fmt.Errorf("--admin-port can't be combined with --proxy-admin, which uses the running proxy's admin API")
+	}
+
 	ctx := context.Background()
 
 	// Load application template
@@ -58,8 +92,28 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load template for %s: %w", appType, err)
 	}
 
+	// Drop leftovers from writes interrupted by a crash; they break builds
+	removed, err := fsutil.CleanPartialWrites(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to clean partial writes: %w", err)
+	}
+	for _, path := range removed {
+		log.Printf("Removed partial write: %s", path)
+	}
+
+	// Make sure the project has ignore files before building its context
+	written, err := template.WriteIgnoreFiles(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to write ignore files: %w", err)
+	}
+	for _, name := range written {
+		log.Printf("Generated %s for %s", name, template.Name)
+	}
+
 	// Generate image name if not provided
-	if imageName == "" {
+	if noBuild {
+		imageName = template.DevImage
+	} else if imageName == "" {
 		mode := "prod"
 		if devMode {
 			mode = "dev"
@@ -79,6 +133,25 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	}
 	defer dockerClient.Close()
 
+	// Previews are only reachable from this machine unless LAN exposure is enabled
+	hostIP := "127.0.0.1"
+	if viper.GetBool("lan") {
+		hostIP = "0.0.0.0"
+	}
+
+	if !staticMode {
+		hostPort, err = dockerClient.AllocateHostPort(ctx, containerName, hostIP, hostPort)
+		if err != nil {
+			return fmt.Errorf("failed to allocate host port: %w", err)
+		}
+		log.Printf("Host port: %s", hostPort)
+	}
+
+	// Generate subdomain
+	subdomain := fmt.Sprintf("preview-%s", containerName)
+	domain := viper.GetString("domain")
+	fullDomain := fmt.Sprintf("%s.%s", subdomain, domain)
+
 	// Build and deploy container
 	deployConfig := &config.DeployConfig{
 		AppType:       appType,
@@ -86,54 +159,140 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		ImageName:     imageName,
 		SourcePath:    sourcePath,
 		HostPort:      hostPort,
+		HostIP:        hostIP,
+		Subdomain:     subdomain,
 		Template:      template,
 		DevMode:       devMode, // Add this
+		NoBuild:       noBuild,
 	}
 
-	containerIP, err := dockerClient.DeployContainer(ctx, deployConfig)
+	if staticMode {
+		outputDir, err := dockerClient.BuildStatic(ctx, deployConfig)
+		if err != nil {
+			return fmt.Errorf("failed to build static export: %w", err)
+		}
+
+		log.Printf("Static site built successfully!")
+		log.Printf("Files: %s", outputDir)
+		log.Printf("Subdomain: %s", fullDomain)
+
+		if !startProxy {
+			return nil
+		}
+		return startProxyServer(dockerClient, subdomain, containerName, outputDir, "")
+	}
+
+	_, err = dockerClient.DeployContainer(ctx, deployConfig)
 	if err != nil {
 		return fmt.Errorf("failed to deploy container: %w", err)
 	}
 
-	// Generate subdomain
-	subdomain := fmt.Sprintf("preview-%s", containerName)
-	domain := viper.GetString("domain")
-	fullDomain := fmt.Sprintf("%s.%s", subdomain, domain)
-
 	log.Printf("Container deployed successfully!")
 	log.Printf("Container: %s", containerName)
 	log.Printf("Image: %s", imageName)
 	log.Printf("Subdomain: %s", fullDomain)
 
+	// --no-build installs dependencies before the dev server starts
+	if noBuild && !cmd.Flags().Changed("ready-timeout") {
+		readyTimeout = 10 * time.Minute
+	}
+
+	if readyTimeout > 0 {
+		log.Printf("Waiting for the app to become ready...")
+		previewURL := fmt.Sprintf("http://localhost:%s", hostPort)
+		err := dockerClient.WaitForReady(ctx, containerName, previewURL, readyTimeout)
+		switch {
+		case errors.Is(err, docker.ErrNotReady):
+			// Still starting; route it anyway so the preview works once it is up
+			log.Printf("⚠️  %v; routing the preview anyway, check 'dock-route logs %s'", err, containerName)
+		case err != nil:
+			return fmt.Errorf("application did not become ready: %w", err)
+		default:
+			log.Printf("✅ Preview is ready at %s", previewURL)
+		}
+	}
+
+	lanIP := ""
+	if viper.GetBool("lan") {
+		lanIP, err = proxy.LANAddress()
+		if err != nil {
+			log.Printf("Warning: LAN exposure enabled but no LAN address found: %v", err)
+		} else {
+			log.Printf("LAN preview: http://%s:%s", lanIP, hostPort)
+		}
+	}
+
 	if devMode {
 		log.Printf("🔥 Development mode enabled - Live editing active!")
 		log.Printf("📁 Watching files in: %s", sourcePath)
 	}
 
+	return routePreview(dockerClient, subdomain, containerName, fmt.Sprintf("http://localhost:%s", hostPort), lanIP)
+}
+
+// routePreview registers the subdomain with a running proxy when one is
+// configured, otherwise starts a proxy for it in the foreground
+func routePreview(dockerClient *docker.Client, subdomain, containerName, targetURL, lanIP string) error {
+	if adminURL := viper.GetString("proxy_admin"); adminURL != "" {
+		if err := proxy.RegisterRoute(adminURL, subdomain, targetURL); err != nil {
+			return fmt.Errorf("failed to register route: %w", err)
+		}
+		log.Printf("Registered %s with proxy at %s", subdomain, adminURL)
+		return nil
+	}
+
 	if startProxy {
-		return startProxyServer(subdomain, containerIP, template.Port)
+		return startProxyServer(dockerClient, subdomain, containerName, targetURL, lanIP)
 	}
 
 	return nil
 }
 
-func startProxyServer(subdomain, containerIP, containerPort string) error {
+func startProxyServer(dockerClient *docker.Client, subdomain, containerName, targetURL, lanIP string) error {
 	pm := proxy.NewManager()
+	if err := restoreRoutes(dockerClient, pm); err != nil {
+		return err
+	}
 
-	targetURL := fmt.Sprintf("http://localhost:%s", hostPort)
-	if err := pm.AddProxy(subdomain, targetURL); err != nil {
+	// Static deploys pass the export directory instead of a URL
+	if staticMode {
+		if err := pm.AddStaticSite(subdomain, targetURL); err != nil {
+			return fmt.Errorf("failed to add static site: %w", err)
+		}
+	} else if err := pm.AddProxy(subdomain, targetURL); err != nil {
 		return fmt.Errorf("failed to add proxy: %w", err)
 	}
 
+	if idleTTL > 0 && !staticMode {
+		reaper := proxy.NewIdleReaper(pm, dockerClient, idleTTL)
+		reaper.Track(subdomain, containerName)
+		go reaper.Run(context.Background(), reapInterval(idleTTL))
+		log.Printf("Idle containers will be stopped after %s without requests", idleTTL)
+	}
+
 	port := viper.GetString("port")
 	domain := viper.GetString("domain")
 
+	var handler http.Handler = pm
+	if lanIP != "" {
+		var err error
+		handler, err = proxy.WithQRCode(pm, fmt.Sprintf("http://%s:%s", lanIP, hostPort))
+		if err != nil {
+			return fmt.Errorf("failed to create QR code: %w", err)
+		}
+		log.Printf("Scan the preview QR code at: http://%s:%s%s", lanIP, port, proxy.QRCodePath)
+	}
+
+	if adminPort != "" {
+		go serveAdminAPI(pm, adminPort)
+	}
+
 	log.Printf("Starting reverse proxy server on :%s", port)
 	log.Printf("Access your application at: %s.%s:%s", subdomain, domain, port)
 
 	server := &http.Server{
 		Addr:              ":" + port,
-		Handler:           pm,
+		Handler:           handler,
 		ReadHeaderTimeout: 5 * time.Second,
 		WriteTimeout:      10 * time.Second,
 		IdleTimeout:       15 * time.Second,
@@ -141,3 +300,63 @@ func startProxyServer(subdomain, containerIP, containerPort string) error {
 	server.ListenAndServe()
 	return nil
 }
+
+// reapInterval is how often idle containers are checked for a given TTL
+func reapInterval(ttl time.Duration) time.Duration {
+	if ttl < time.Minute {
+		return ttl
+	}
+	return time.Minute
+}
+
+// restoreRoutes reloads the routes saved by earlier proxy runs and points
+// them at the ports their containers are published on now
+func restoreRoutes(dockerClient *docker.Client, pm *proxy.Manager) error {
+	statePath, err := proxyStatePath()
+	if err != nil {
+		return err
+	}
+	if err := pm.Persist(statePath); err != nil {
+		return fmt.Errorf("failed to load proxy state: %w", err)
+	}
+
+	routes, err := dockerClient.PreviewRoutes(context.Background())
+	if err != nil {
+		log.Printf("Warning: could not resolve routes from Docker: %v", err)
+		return nil
+	}
+	for subdomain, targetURL := range routes {
+		if err := pm.AddProxy(subdomain, targetURL); err != nil {
+			log.Printf("Warning: failed to restore route %s: %v", subdomain, err)
+		}
+	}
+
+	return nil
+}
+
+// proxyStatePath returns where proxy routes are saved between runs
+func proxyStatePath() (string, error) {
+	if path := viper.GetString("proxy_state"); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".dock-route", "routes.yaml"), nil
+}
+
+// serveAdminAPI exposes route management on localhost only
+func serveAdminAPI(pm *proxy.Manager, port string) {
+	log.Printf("Proxy admin API listening on 127.0.0.1:%s", port)
+
+	server := &http.Server{
+		Addr:              "127.0.0.1:" + port,
+		Handler:           pm.AdminHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Printf("Proxy admin API failed: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lahiruramesh/dock-route/internal/docker"
+	"github.com/spf13/cobra"
+)
+
+var healthCmd = &cobra.Command{
+	Use:   "health [container-name]",
+	Short: "Check whether a deployed app is answering requests",
+	Long: `Probe the app running in a managed container over HTTP. Exits with an
+error when the container is stopped or the app does not respond.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHealth,
+}
+
+func init() {
+	rootCmd.AddCommand(healthCmd)
+}
+
+func runHealth(cmd *cobra.Command, args []string) error {
+	containerName := args[0]
+	ctx := context.Background()
+
+	dockerClient, err := docker.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer dockerClient.Close()
+
+	info, err := dockerClient.GetContainerInfo(ctx, containerName)
+	if err != nil {
+		return err
+	}
+	if info.State != "running" {
+		return fmt.Errorf("container '%s' is %s", containerName, info.State)
+	}
+
+	previewURL, err := dockerClient.PreviewURL(ctx, containerName)
+	if err != nil {
+		return err
+	}
+
+	if err := docker.ProbeHTTP(ctx, previewURL); err != nil {
+		return fmt.Errorf("'%s' is not ready: %w", containerName, err)
+	}
+
+	fmt.Printf("Container '%s' is ready at %s\n", containerName, previewURL)
+	return nil
+}
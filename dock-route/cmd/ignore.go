@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/lahiruramesh/dock-route/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var ignoreCmd = &cobra.Command{
+	Use:   "ignore-files [app-type] [source-path]",
+	Short: "Generate missing .gitignore and .dockerignore files",
+	Long: `Generate framework-appropriate .gitignore and .dockerignore files for an
+existing project. Files that already exist are left untouched.
+
+Example:
+  dock-route ignore-files nextjs ./my-next-project`,
+	Args: cobra.ExactArgs(2),
+	RunE: runIgnore,
+}
+
+func init() {
+	rootCmd.AddCommand(ignoreCmd)
+}
+
+func runIgnore(cmd *cobra.Command, args []string) error {
+	appType := args[0]
+	sourcePath := args[1]
+
+	templateManager := templates.NewManager()
+	template, err := templateManager.GetTemplate(appType)
+	if err != nil {
+		return fmt.Errorf("failed to load template for %s: %w", appType, err)
+	}
+
+	written, err := template.WriteIgnoreFiles(sourcePath)
+	if err != nil {
+		return err
+	}
+
+	if len(written) == 0 {
+		fmt.Println("Ignore files already present, nothing to do.")
+		return nil
+	}
+
+	for _, name := range written {
+		fmt.Printf("Created %s\n", name)
+	}
+
+	return nil
+}
@@ -53,6 +53,13 @@ func listTemplates() error {
 
 		fmt.Printf("- **%s**: %s\n", template.Name, template.Description)
 		fmt.Printf("  Port: %s, Mount: %s\n", template.Port, template.MountPath)
+		if remote, err := templateManager.Remote(templateType); err == nil {
+			ref := remote.Ref
+			if ref == "" {
+				ref = "default branch"
+			}
+			fmt.Printf("  Source: %s (%s)\n", remote.URL, ref)
+		}
 		fmt.Println()
 	}
 
@@ -86,6 +93,9 @@ func listContainers() error {
 		fmt.Printf("  Status: %s\n", container.Status)
 		fmt.Printf("  Ports: %s\n", container.Ports)
 		fmt.Printf("  Subdomain: preview-%s.dock-route.local\n", container.Name)
+		if container.HostPort != "" {
+			fmt.Printf("  Host Port: %s\n", container.HostPort)
+		}
 		fmt.Println()
 	}
 
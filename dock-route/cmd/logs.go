@@ -2,16 +2,20 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"log"
+	"os"
 
+	"github.com/lahiruramesh/dock-route/internal/buildlog"
 	"github.com/lahiruramesh/dock-route/internal/docker"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	follow bool
-	tail   string
+	follow     bool
+	tail       string
+	showErrors bool
 )
 
 var logsCmd = &cobra.Command{
@@ -29,6 +33,13 @@ var logsCmd = &cobra.Command{
 		}
 		defer dockerClient.Close()
 
+		if showErrors {
+			if err := printBuildErrors(ctx, dockerClient, containerName); err != nil {
+				log.Fatalf("Failed to parse logs for container '%s': %v", containerName, err)
+			}
+			return
+		}
+
 		err = dockerClient.ShowLogs(ctx, containerName, follow, tail)
 		if err != nil {
 			log.Fatalf("Failed to show logs for container '%s': %v", containerName, err)
@@ -41,4 +52,23 @@ func init() {
 
 	logsCmd.Flags().BoolVarP(&follow, "follow", "f", false, "Follow log output")
 	logsCmd.Flags().StringVarP(&tail, "tail", "t", "100", "Number of lines to show from the end of the logs")
+	logsCmd.Flags().BoolVar(&showErrors, "errors", false, "Print build errors found in the logs as JSON")
+}
+
+// printBuildErrors writes the Vite/Next.js errors in the log tail as JSON
+func printBuildErrors(ctx context.Context, dockerClient *docker.Client, containerName string) error {
+	output, err := dockerClient.GetLogs(ctx, containerName, tail)
+	if err != nil {
+		return err
+	}
+
+	errors := buildlog.Parse(output)
+	if errors == nil {
+		errors = []buildlog.BuildError{}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(errors)
 }
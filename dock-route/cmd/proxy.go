@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/lahiruramesh/dock-route/internal/docker"
+	"github.com/lahiruramesh/dock-route/internal/proxy"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var proxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Run the reverse proxy on its own",
+}
+
+var proxyServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Start the reverse proxy with an admin API for managing routes",
+	Long: `Start the reverse proxy without deploying anything. Routes saved by earlier
+runs are restored, and the admin API on localhost lets the API server or CI
+add, remove and list routes:
+
+  GET    /routes                    list routes
+  POST   /routes                    add or replace a route ({"subdomain": "...", "target": "..."})
+  DELETE /routes/{subdomain}        remove a route
+  POST   /routes/{subdomain}/touch  record activity so an idle container is not stopped
+
+Deploy with --proxy-admin to register new previews with a running proxy.
+
+Example:
+  dock-route proxy serve --admin-port 9080
+  dock-route deploy reactjs my-app ./src --proxy-admin http://127.0.0.1:9080`,
+	Args: cobra.NoArgs,
+	RunE: runProxyServe,
+}
+
+var (
+	proxyAdminPort string
+	proxyIdleTTL   time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(proxyCmd)
+	proxyCmd.AddCommand(proxyServeCmd)
+
+	proxyServeCmd.Flags().StringVar(&proxyAdminPort, "admin-port", "9080", "Localhost port for the admin API")
+	proxyServeCmd.Flags().DurationVar(&proxyIdleTTL, "idle-ttl", 0, "Stop deployed containers after this long without requests or touches and restart them on next access (0 disables)")
+}
+
+func runProxyServe(cmd *cobra.Command, args []string) error {
+	dockerClient, err := docker.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer dockerClient.Close()
+
+	server := proxy.NewServer(viper.GetString("port"))
+	if err := restoreRoutes(dockerClient, server.Manager()); err != nil {
+		return err
+	}
+
+	if proxyIdleTTL > 0 {
+		reaper := proxy.NewIdleReaper(server.Manager(), dockerClient, proxyIdleTTL)
+		go trackPreviewContainers(dockerClient, reaper, reapInterval(proxyIdleTTL))
+		go reaper.Run(context.Background(), reapInterval(proxyIdleTTL))
+		log.Printf("Idle containers will be stopped after %s without requests", proxyIdleTTL)
+	}
+
+	go serveAdminAPI(server.Manager(), proxyAdminPort)
+
+	// Shut down cleanly so in-flight requests finish
+	go func() {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+		<-sigs
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Stop(ctx); err != nil {
+			log.Printf("Warning: proxy shutdown failed: %v", err)
+		}
+	}()
+
+	log.Printf("Serving %d route(s) for *.%s", len(server.GetActiveProxies()), viper.GetString("domain"))
+	return server.Start()
+}
+
+// trackPreviewContainers keeps the reaper's subdomain to container mapping in
+// sync with Docker, so containers deployed after startup are reaped too
+func trackPreviewContainers(dockerClient *docker.Client, reaper *proxy.IdleReaper, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		containers, err := dockerClient.PreviewContainers(context.Background())
+		if err != nil {
+			log.Printf("Warning: failed to list preview containers: %v", err)
+		}
+		for subdomain, containerName := range containers {
+			reaper.Track(subdomain, containerName)
+		}
+
+		<-ticker.C
+	}
+}
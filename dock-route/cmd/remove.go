@@ -6,7 +6,9 @@ import (
 	"log"
 
 	"github.com/lahiruramesh/dock-route/internal/docker"
+	"github.com/lahiruramesh/dock-route/internal/proxy"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var removeCmd = &cobra.Command{
@@ -39,6 +41,15 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	}
 	defer dockerClient.Close()
 
+	// Static sites are served by the proxy without a container
+	status, err := dockerClient.GetContainerStatus(ctx, containerName)
+	if err != nil {
+		return err
+	}
+	if status == "not found" {
+		return removeStaticSite(containerName)
+	}
+
 	log.Printf("Removing container: %s", containerName)
 
 	imageName, err := dockerClient.RemoveContainer(ctx, containerName, forceRemove)
@@ -57,8 +68,48 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	unrouteDeployment(containerName)
+
 	fmt.Printf("Deployment '%s' has been removed.\n", containerName)
 	fmt.Printf("Subdomain 'preview-%s.domain.localhost' is no longer accessible.\n", containerName)
 
 	return nil
 }
+
+// removeStaticSite removes a deployment made with --static, which only
+// exists as a proxy route
+func removeStaticSite(name string) error {
+	if !unrouteDeployment(name) {
+		return fmt.Errorf("no container or static site named '%s'", name)
+	}
+
+	fmt.Printf("Static site '%s' has been removed.\n", name)
+	fmt.Printf("Subdomain 'preview-%s.domain.localhost' is no longer accessible.\n", name)
+
+	return nil
+}
+
+// unrouteDeployment drops a deployment's route from the running proxy and
+// from the saved state, so the next proxy run does not restore it. It
+// reports whether a saved route was removed.
+func unrouteDeployment(name string) bool {
+	subdomain := "preview-" + name
+
+	if adminURL := viper.GetString("proxy_admin"); adminURL != "" {
+		if err := proxy.UnregisterRoute(adminURL, subdomain); err != nil {
+			log.Printf("Warning: failed to unregister route: %v", err)
+		}
+	}
+
+	statePath, err := proxyStatePath()
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return false
+	}
+
+	forgotten, err := proxy.ForgetRoute(statePath, subdomain)
+	if err != nil {
+		log.Printf("Warning: failed to update proxy state: %v", err)
+	}
+	return forgotten
+}
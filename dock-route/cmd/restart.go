@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/lahiruramesh/dock-route/internal/docker"
+
+	"github.com/spf13/cobra"
+)
+
+var restartCmd = &cobra.Command{
+	Use:   "restart [container-name]",
+	Short: "Restart a container",
+	Long:  `Restart a Docker container managed by dock-route, starting it if it is stopped.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		containerName := args[0]
+
+		ctx := context.Background()
+		dockerClient, err := docker.NewClient()
+		if err != nil {
+			log.Fatalf("Failed to create Docker client: %v", err)
+		}
+		defer dockerClient.Close()
+
+		err = dockerClient.RestartContainer(ctx, containerName)
+		if err != nil {
+			log.Fatalf("Failed to restart container '%s': %v", containerName, err)
+		}
+
+		fmt.Printf("Container '%s' restarted successfully.\n", containerName)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restartCmd)
+}
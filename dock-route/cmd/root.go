@@ -28,9 +28,13 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.dock-route.yaml)")
 	rootCmd.PersistentFlags().StringP("port", "p", "8080", "Port for the reverse proxy server")
 	rootCmd.PersistentFlags().StringP("domain", "d", "aicodeagent.abc", "Base domain for subdomains")
+	rootCmd.PersistentFlags().String("proxy-admin", "", "Admin API URL of a running dock-route proxy to register routes with")
+	rootCmd.PersistentFlags().Bool("lan", false, "Expose previews on all interfaces so devices on the local network can reach them")
 
 	viper.BindPFlag("port", rootCmd.PersistentFlags().Lookup("port"))
 	viper.BindPFlag("domain", rootCmd.PersistentFlags().Lookup("domain"))
+	viper.BindPFlag("proxy_admin", rootCmd.PersistentFlags().Lookup("proxy-admin"))
+	viper.BindPFlag("lan", rootCmd.PersistentFlags().Lookup("lan"))
 }
 
 func initConfig() {
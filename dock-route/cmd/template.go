@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/lahiruramesh/dock-route/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage application templates fetched from git",
+	Long: `Register application templates maintained in git repositories. A template
+repository holds template.yaml and Dockerfile at its root. Git templates are
+cached under ~/.dock-route/templates and take precedence over built-in
+templates with the same name.`,
+}
+
+var templateAddCmd = &cobra.Command{
+	Use:   "add [name] [git-url]",
+	Short: "Register a template from a git repository",
+	Long: `Clone a template repository and register it under the given name.
+
+Example:
+  dock-route template add vite-shadcn https://github.com/acme/vite-shadcn-template --ref v1.2.0`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := templates.NewManager().AddRemote(args[0], args[1], templateRef); err != nil {
+			return fmt.Errorf("failed to add template: %w", err)
+		}
+
+		fmt.Printf("Template '%s' added.\n", args[0])
+		return nil
+	},
+}
+
+var templateUpdateCmd = &cobra.Command{
+	Use:   "update [name]",
+	Short: "Re-fetch a git template at its registered ref",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := templates.NewManager().UpdateRemote(args[0]); err != nil {
+			return fmt.Errorf("failed to update template: %w", err)
+		}
+
+		fmt.Printf("Template '%s' updated.\n", args[0])
+		return nil
+	},
+}
+
+var templateRemoveCmd = &cobra.Command{
+	Use:   "remove [name]",
+	Short: "Unregister a git template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := templates.NewManager().RemoveRemote(args[0]); err != nil {
+			return fmt.Errorf("failed to remove template: %w", err)
+		}
+
+		fmt.Printf("Template '%s' removed.\n", args[0])
+		return nil
+	},
+}
+
+var templateRef string
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateAddCmd, templateUpdateCmd, templateRemoveCmd)
+
+	templateAddCmd.Flags().StringVar(&templateRef, "ref", "", "Branch or tag to pin (default: the repository's default branch)")
+}
@@ -0,0 +1,162 @@
+// Package buildlog extracts structured errors from Vite and Next.js dev
+// server output so callers don't have to pass raw log blobs around.
+package buildlog
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BuildError is a single compile error reported by a dev server.
+type BuildError struct {
+	Source    string `json:"source"`
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	Message   string `json:"message"`
+	CodeFrame string `json:"code_frame,omitempty"`
+}
+
+var (
+	ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+	// [vite] Internal server error: /app/src/App.tsx: Unexpected token (12:5)
+	// [plugin:vite:react-babel] /app/src/App.tsx: Unexpected token (12:5)
+	vitePattern = regexp.MustCompile(`\[(?:vite|plugin:[^\]]+)\]\s*(?:Internal server error:\s*)?(?:Pre-transform error:\s*)?(\S+?):\s*(.+?)\s*\((\d+):(\d+)\)\s*$`)
+
+	// ✘ [ERROR] Expected ";" but found "}"
+	esbuildPattern         = regexp.MustCompile(`\[ERROR\]\s*(.+)$`)
+	esbuildLocationPattern = regexp.MustCompile(`^\s+(\S+):(\d+):(\d+):\s*$`)
+
+	// ⨯ ./src/app/page.tsx:12:5
+	nextPattern = regexp.MustCompile(`^\s*(?:⨯\s*)?(\./\S+?):(\d+):(\d+)\s*$`)
+
+	// src/App.tsx(12,5): error TS2322: ... and src/App.tsx:12:5 - error TS2322: ...
+	tscPattern = regexp.MustCompile(`^\s*(\S+?)(?:\((\d+),(\d+)\):|:(\d+):(\d+) -) error (TS\d+: .+)$`)
+
+	codeFramePattern = regexp.MustCompile(`^\s*>?\s*\d*\s*[|│╵]`)
+)
+
+// Parse scans dev server output and returns the errors it recognizes, in the
+// order they appear, with duplicates removed.
+func Parse(output string) []BuildError {
+	lines := strings.Split(ansiPattern.ReplaceAllString(output, ""), "\n")
+
+	var errors []BuildError
+	seen := make(map[string]bool)
+	add := func(e BuildError) {
+		key := e.File + ":" + strconv.Itoa(e.Line) + ":" + strconv.Itoa(e.Column) + ":" + e.Message
+		if !seen[key] {
+			seen[key] = true
+			errors = append(errors, e)
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+
+		if m := vitePattern.FindStringSubmatch(line); m != nil {
+			frame, next := codeFrame(lines, i+1)
+			add(BuildError{
+				Source:    "vite",
+				File:      m[1],
+				Line:      atoi(m[3]),
+				Column:    atoi(m[4]),
+				Message:   m[2],
+				CodeFrame: frame,
+			})
+			i = next - 1
+			continue
+		}
+
+		if m := esbuildPattern.FindStringSubmatch(line); m != nil {
+			for j := i + 1; j < len(lines) && j <= i+3; j++ {
+				loc := esbuildLocationPattern.FindStringSubmatch(lines[j])
+				if loc == nil {
+					continue
+				}
+				frame, next := codeFrame(lines, j+1)
+				add(BuildError{
+					Source:    "vite",
+					File:      loc[1],
+					Line:      atoi(loc[2]),
+					Column:    atoi(loc[3]),
+					Message:   strings.TrimSpace(m[1]),
+					CodeFrame: frame,
+				})
+				i = next - 1
+				break
+			}
+			continue
+		}
+
+		if m := nextPattern.FindStringSubmatch(line); m != nil {
+			message, next := nextMessage(lines, i+1)
+			frame, next := codeFrame(lines, next)
+			add(BuildError{
+				Source:    "next",
+				File:      m[1],
+				Line:      atoi(m[2]),
+				Column:    atoi(m[3]),
+				Message:   message,
+				CodeFrame: frame,
+			})
+			i = next - 1
+			continue
+		}
+
+		if m := tscPattern.FindStringSubmatch(line); m != nil {
+			lineNo, col := m[2], m[3]
+			if lineNo == "" {
+				lineNo, col = m[4], m[5]
+			}
+			add(BuildError{
+				Source:  "tsc",
+				File:    m[1],
+				Line:    atoi(lineNo),
+				Column:  atoi(col),
+				Message: m[6],
+			})
+		}
+	}
+
+	return errors
+}
+
+// nextMessage returns the first non-frame, non-empty line after a Next.js
+// location line, which holds the error description.
+func nextMessage(lines []string, start int) (string, int) {
+	for i := start; i < len(lines) && i < start+3; i++ {
+		text := strings.TrimSpace(lines[i])
+		if text == "" || codeFramePattern.MatchString(lines[i]) {
+			continue
+		}
+		return text, i + 1
+	}
+	return "", start
+}
+
+// codeFrame collects the source excerpt that follows an error, returning it
+// together with the index of the first line after it.
+func codeFrame(lines []string, start int) (string, int) {
+	i := start
+	for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+		i++
+	}
+
+	var frame []string
+	for ; i < len(lines) && codeFramePattern.MatchString(lines[i]); i++ {
+		frame = append(frame, strings.TrimRight(lines[i], "\r"))
+	}
+	if len(frame) == 0 {
+		return "", start
+	}
+
+	return strings.Join(frame, "\n"), i
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
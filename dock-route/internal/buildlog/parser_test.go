@@ -0,0 +1,145 @@
+package buildlog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []BuildError
+	}{
+		{
+			name: "vite internal server error with code frame",
+			output: "12:01:33 PM [vite] Internal server error: /app/src/App.tsx: Unexpected token (12:5)\n" +
+				"  10 |   return (\n" +
+				"  11 |     <div>\n" +
+				"> 12 |     <p>\n" +
+				"     |     ^\n" +
+				"      at constructor (/app/node_modules/@babel/parser/lib/index.js:356:19)\n",
+			want: []BuildError{{
+				Source:  "vite",
+				File:    "/app/src/App.tsx",
+				Line:    12,
+				Column:  5,
+				Message: "Unexpected token",
+				CodeFrame: "  10 |   return (\n" +
+					"  11 |     <div>\n" +
+					"> 12 |     <p>\n" +
+					"     |     ^",
+			}},
+		},
+		{
+			name:   "vite plugin error",
+			output: "[plugin:vite:react-babel] /app/src/main.tsx: Missing semicolon. (3:14)\n",
+			want: []BuildError{{
+				Source:  "vite",
+				File:    "/app/src/main.tsx",
+				Line:    3,
+				Column:  14,
+				Message: "Missing semicolon.",
+			}},
+		},
+		{
+			name: "ANSI coloured vite error",
+			output: "\x1b[2m12:01:33 PM\x1b[22m \x1b[31m\x1b[1m[vite]\x1b[22m\x1b[39m \x1b[31mInternal server error: " +
+				"/app/src/App.tsx: Unexpected token (7:2)\x1b[39m\n",
+			want: []BuildError{{
+				Source:  "vite",
+				File:    "/app/src/App.tsx",
+				Line:    7,
+				Column:  2,
+				Message: "Unexpected token",
+			}},
+		},
+		{
+			name: "esbuild error",
+			output: "✘ [ERROR] Expected \";\" but found \"}\"\n" +
+				"\n" +
+				"    src/utils.ts:4:17:\n" +
+				"      4 │   const a = 1 b\n" +
+				"        ╵                  ^\n",
+			want: []BuildError{{
+				Source:  "vite",
+				File:    "src/utils.ts",
+				Line:    4,
+				Column:  17,
+				Message: "Expected \";\" but found \"}\"",
+				CodeFrame: "      4 │   const a = 1 b\n" +
+					"        ╵                  ^",
+			}},
+		},
+		{
+			name: "next.js module error",
+			output: " ⨯ ./src/app/page.tsx:12:5\n" +
+				"Module not found: Can't resolve './missing'\n" +
+				"  10 | import Link from 'next/link'\n" +
+				"> 12 | import Missing from './missing'\n",
+			want: []BuildError{{
+				Source:  "next",
+				File:    "./src/app/page.tsx",
+				Line:    12,
+				Column:  5,
+				Message: "Module not found: Can't resolve './missing'",
+				CodeFrame: "  10 | import Link from 'next/link'\n" +
+					"> 12 | import Missing from './missing'",
+			}},
+		},
+		{
+			name: "tsc errors in both formats",
+			output: "src/App.tsx(12,5): error TS2322: Type 'string' is not assignable to type 'number'.\n" +
+				"src/main.tsx:3:1 - error TS2304: Cannot find name 'foo'.\n",
+			want: []BuildError{
+				{
+					Source:  "tsc",
+					File:    "src/App.tsx",
+					Line:    12,
+					Column:  5,
+					Message: "TS2322: Type 'string' is not assignable to type 'number'.",
+				},
+				{
+					Source:  "tsc",
+					File:    "src/main.tsx",
+					Line:    3,
+					Column:  1,
+					Message: "TS2304: Cannot find name 'foo'.",
+				},
+			},
+		},
+		{
+			name: "duplicate errors are reported once",
+			output: "[vite] Internal server error: /app/src/App.tsx: Unexpected token (12:5)\n" +
+				"[vite] Internal server error: /app/src/App.tsx: Unexpected token (12:5)\n",
+			want: []BuildError{{
+				Source:  "vite",
+				File:    "/app/src/App.tsx",
+				Line:    12,
+				Column:  5,
+				Message: "Unexpected token",
+			}},
+		},
+		{
+			name: "normal dev server output",
+			output: "  VITE v5.4.2  ready in 312 ms\n" +
+				"\n" +
+				"  ➜  Local:   http://localhost:5173/\n" +
+				"12:03:10 PM [vite] hmr update /src/App.tsx\n" +
+				"   ▲ Next.js 14.2.5\n" +
+				" ✓ Compiled /page in 1.2s (512 modules)\n" +
+				"src/App.tsx:12:5 - warning: unused variable\n" +
+				"[ERROR] without a location line\n",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.output)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
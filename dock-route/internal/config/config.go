@@ -8,8 +8,11 @@ type DeployConfig struct {
     ImageName     string
     SourcePath    string
     HostPort      string
+    HostIP        string
+    Subdomain     string
     Template      *templates.Template
     DevMode       bool
+    NoBuild       bool
 }
 
 type ProxyConfig struct {
@@ -18,8 +18,12 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 	"github.com/lahiruramesh/dock-route/internal/config"
+	"github.com/lahiruramesh/dock-route/internal/fsutil"
+	"github.com/lahiruramesh/dock-route/internal/templates"
+	"github.com/moby/patternmatcher"
 )
 
 type Client struct {
@@ -40,6 +44,10 @@ func (c *Client) Close() error {
 }
 
 func (c *Client) DeployContainer(ctx context.Context, config *config.DeployConfig) (string, error) {
+	if config.NoBuild {
+		return c.RunDevContainer(ctx, config)
+	}
+
 	// Build Docker image
 	if err := c.buildImage(ctx, config); err != nil {
 		return "", fmt.Errorf("failed to build image: %w", err)
@@ -54,6 +62,36 @@ func (c *Client) DeployContainer(ctx context.Context, config *config.DeployConfi
 	return containerIP, nil
 }
 
+// RunDevContainer starts the template's stock dev image with the project
+// bind-mounted, installing dependencies and running the dev command on start.
+// No image is built, so edits are live without a Dockerfile round-trip.
+func (c *Client) RunDevContainer(ctx context.Context, config *config.DeployConfig) (string, error) {
+	if config.Template.DevImage == "" || len(config.Template.DevCommand) == 0 {
+		return "", fmt.Errorf("template %s does not support running without a build", config.Template.Name)
+	}
+
+	exists, err := c.ImageExists(ctx, config.Template.DevImage)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		log.Printf("Pulling image '%s'...", config.Template.DevImage)
+		if err := c.PullImage(ctx, config.Template.DevImage); err != nil {
+			return "", err
+		}
+	}
+
+	config.ImageName = config.Template.DevImage
+	config.DevMode = true
+
+	containerIP, err := c.startContainer(ctx, config)
+	if err != nil {
+		return "", fmt.Errorf("failed to start container: %w", err)
+	}
+
+	return containerIP, nil
+}
+
 func (c *Client) buildImage(ctx context.Context, config *config.DeployConfig) error {
 	log.Printf("Building Docker image '%s'...", config.ImageName)
 
@@ -147,8 +185,20 @@ func (c *Client) createBuildContext(config *config.DeployConfig) (io.Reader, fun
 			return
 		}
 
+		// Honour the project's .dockerignore on top of the built-in exclusions
+		ignorePatterns, err := templates.ReadIgnoreFile(filepath.Join(config.SourcePath, ".dockerignore"))
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		matcher, err := newContextMatcher(ignorePatterns)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
 		// Add source files with exclusions
-		err := filepath.Walk(config.SourcePath, func(file string, fi os.FileInfo, err error) error {
+		err = filepath.Walk(config.SourcePath, func(file string, fi os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
@@ -163,8 +213,12 @@ func (c *Client) createBuildContext(config *config.DeployConfig) (io.Reader, fun
 			}
 
 			// Skip excluded files and directories
-			if c.shouldExclude(relPath) {
-				if fi.IsDir() {
+			excluded, err := matcher.MatchesOrParentMatches(filepath.ToSlash(relPath))
+			if err != nil {
+				return fmt.Errorf("failed to match %s against .dockerignore: %w", relPath, err)
+			}
+			if excluded {
+				if fi.IsDir() && !mayReinclude(matcher, relPath) {
 					return filepath.SkipDir
 				}
 				return nil
@@ -215,44 +269,66 @@ func (c *Client) createBuildContext(config *config.DeployConfig) (io.Reader, fun
 	return pr, cleanup, nil
 }
 
-// shouldExclude determines if a file/directory should be excluded from the build context
-func (c *Client) shouldExclude(relPath string) bool {
-	excludePatterns := []string{
-		"node_modules",
-		".git",
-		".gitignore",
-		".dockerignore",
-		".next",
-		"dist",
-		"build",
-		".vscode",
-		".idea",
-		"*.log",
-		".env",
-		".env.local",
-		".env.development.local",
-		".env.test.local",
-		".env.production.local",
-		"coverage",
-		".nyc_output",
-		".cache",
-		"tmp",
-		"temp",
-	}
-
-	for _, pattern := range excludePatterns {
-		if matched, _ := filepath.Match(pattern, filepath.Base(relPath)); matched {
-			return true
+// builtinExcludes are left out of every build context, wherever they
+// appear in the tree.
+var builtinExcludes = []string{
+	"node_modules",
+	".git",
+	".gitignore",
+	".dockerignore",
+	".next",
+	"dist",
+	"build",
+	".vscode",
+	".idea",
+	"*.log",
+	".env",
+	".env.local",
+	".env.development.local",
+	".env.test.local",
+	".env.production.local",
+	"coverage",
+	".nyc_output",
+	".cache",
+	"tmp",
+	"temp",
+}
+
+// newContextMatcher combines the built-in exclusions with the project's
+// .dockerignore patterns. The project patterns come last, so they follow
+// Docker's rules: they are relative to the context root, support "**", and
+// a later "!" pattern re-includes what an earlier one excluded.
+func newContextMatcher(ignorePatterns []string) (*patternmatcher.PatternMatcher, error) {
+	patterns := make([]string, 0, len(builtinExcludes)+len(ignorePatterns))
+	for _, pattern := range builtinExcludes {
+		patterns = append(patterns, "**/"+pattern)
+	}
+	patterns = append(patterns, ignorePatterns...)
+
+	matcher, err := patternmatcher.New(patterns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse .dockerignore patterns: %w", err)
+	}
+	return matcher, nil
+}
+
+// mayReinclude reports whether a "!" pattern could re-include something
+// inside the excluded directory dir, in which case it has to be walked
+// rather than skipped. This mirrors the check Docker makes when it builds
+// a context.
+func mayReinclude(matcher *patternmatcher.PatternMatcher, dir string) bool {
+	if !matcher.Exclusions() {
+		return false
+	}
+	dirSlash := filepath.ToSlash(dir) + "/"
+	for _, pattern := range matcher.Patterns() {
+		if !pattern.Exclusion() {
+			continue
 		}
-		// Check if any parent directory matches the pattern
-		parts := strings.Split(relPath, string(filepath.Separator))
-		for _, part := range parts {
-			if matched, _ := filepath.Match(pattern, part); matched {
-				return true
-			}
+		if strings.HasPrefix(pattern.String()+"/", dirSlash) {
+			return true
 		}
 	}
-
 	return false
 }
 
@@ -277,7 +353,9 @@ func (c *Client) startContainer(ctx context.Context, config *config.DeployConfig
 
 	// Prepare container command based on mode
 	var cmd []string
-	if config.DevMode && len(config.Template.DevCommand) > 0 {
+	if config.NoBuild {
+		cmd = []string{"sh", "-c", installAndExec(config.Template.Install, config.Template.DevCommand)}
+	} else if config.DevMode && len(config.Template.DevCommand) > 0 {
 		cmd = config.Template.DevCommand
 	} else if len(config.Template.ProdCommand) > 0 {
 		cmd = config.Template.ProdCommand
@@ -288,11 +366,18 @@ func (c *Client) startContainer(ctx context.Context, config *config.DeployConfig
 		ExposedPorts: exposedPorts,
 		Env:          c.buildEnvVars(config.Template.Environment),
 		Labels: map[string]string{
-			"managed-by": "dock-route",
-			"mode":       c.getMode(config.DevMode),
+			"managed-by":  "dock-route",
+			"mode":        c.getMode(config.DevMode),
+			HostPortLabel: config.HostPort,
 		},
 		WorkingDir: config.Template.MountPath,
 	}
+	if config.Subdomain != "" {
+		containerConfig.Labels[SubdomainLabel] = config.Subdomain
+	}
+	if config.NoBuild {
+		containerConfig.Labels[StockImageLabel] = "true"
+	}
 
 	// Set command if specified
 	if len(cmd) > 0 {
@@ -303,7 +388,7 @@ func (c *Client) startContainer(ctx context.Context, config *config.DeployConfig
 	hostConfig := &container.HostConfig{
 		PortBindings: nat.PortMap{
 			nat.Port(config.Template.Port + "/tcp"): []nat.PortBinding{
-				{HostIP: "0.0.0.0", HostPort: config.HostPort},
+				{HostIP: config.HostIP, HostPort: config.HostPort},
 			},
 		},
 	}
@@ -357,6 +442,21 @@ func (c *Client) startContainer(ctx context.Context, config *config.DeployConfig
 	return containerIP, nil
 }
 
+// installAndExec installs dependencies, then replaces the shell with the
+// given command so it receives signals directly
+func installAndExec(install string, command []string) string {
+	quoted := make([]string, len(command))
+	for i, arg := range command {
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+
+	script := "exec " + strings.Join(quoted, " ")
+	if install != "" {
+		script = install + " && " + script
+	}
+	return script
+}
+
 func (c *Client) getMode(devMode bool) string {
 	if devMode {
 		return "development"
@@ -535,15 +635,12 @@ func (c *Client) copyFileFromContainer(ctx context.Context, containerID, srcPath
 		}
 
 		if header.Typeflag == tar.TypeReg {
-			// Create host file
-			outFile, err := os.Create(dstPath)
-			if err != nil {
-				return fmt.Errorf("failed to create host file: %w", err)
-			}
-			defer outFile.Close()
-
-			// Copy content
-			_, err = io.Copy(outFile, tr)
+			// Replace the host file atomically, keeping the mode the file has in the container
+			mode := os.FileMode(header.Mode).Perm()
+			err := fsutil.WriteAtomic(dstPath, mode, func(w io.Writer) error {
+				_, err := io.Copy(w, tr)
+				return err
+			})
 			if err != nil {
 				return fmt.Errorf("failed to write file content: %w", err)
 			}
@@ -632,6 +729,31 @@ func (c *Client) StopContainer(ctx context.Context, containerName string) error
 	return nil
 }
 
+// RestartContainer restarts a container, starting it if it is stopped
+func (c *Client) RestartContainer(ctx context.Context, containerName string) error {
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("name", containerName)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	if len(containers) == 0 {
+		return fmt.Errorf("container '%s' not found", containerName)
+	}
+
+	timeout := 10 // seconds
+	err = c.cli.ContainerRestart(ctx, containers[0].ID, container.StopOptions{
+		Timeout: &timeout,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restart container: %w", err)
+	}
+
+	return nil
+}
+
 // ShowLogs displays container logs
 func (c *Client) ShowLogs(ctx context.Context, containerName string, follow bool, tail string) error {
 	containers, err := c.cli.ContainerList(ctx, container.ListOptions{
@@ -656,17 +778,68 @@ func (c *Client) ShowLogs(ctx context.Context, containerName string, follow bool
 		Timestamps: true,
 	}
 
+	containerJSON, err := c.cli.ContainerInspect(ctx, containerInfo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+
 	logs, err := c.cli.ContainerLogs(ctx, containerInfo.ID, options)
 	if err != nil {
 		return fmt.Errorf("failed to get container logs: %w", err)
 	}
 	defer logs.Close()
 
-	// Stream logs to stdout
-	_, err = io.Copy(os.Stdout, logs)
+	// Containers without a TTY multiplex stdout and stderr into one stream
+	if containerJSON.Config != nil && containerJSON.Config.Tty {
+		_, err = io.Copy(os.Stdout, logs)
+	} else {
+		_, err = stdcopy.StdCopy(os.Stdout, os.Stderr, logs)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to stream logs: %w", err)
 	}
 
 	return nil
 }
+
+// GetLogs returns the last tail lines of a container's output as text
+func (c *Client) GetLogs(ctx context.Context, containerName string, tail string) (string, error) {
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("name", containerName)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	if len(containers) == 0 {
+		return "", fmt.Errorf("container '%s' not found", containerName)
+	}
+
+	containerJSON, err := c.cli.ContainerInspect(ctx, containers[0].ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	logs, err := c.cli.ContainerLogs(ctx, containers[0].ID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       tail,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get container logs: %w", err)
+	}
+	defer logs.Close()
+
+	var output strings.Builder
+	if containerJSON.Config != nil && containerJSON.Config.Tty {
+		_, err = io.Copy(&output, logs)
+	} else {
+		_, err = stdcopy.StdCopy(&output, &output, logs)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read logs: %w", err)
+	}
+
+	return output.String(), nil
+}
@@ -9,12 +9,21 @@ import (
 	"github.com/docker/docker/api/types/filters"
 )
 
+// SubdomainLabel records the proxy subdomain a container was deployed for
+const SubdomainLabel = "dock-route.subdomain"
+
+// StockImageLabel marks containers running a shared stock image, such as the
+// dev image used by --no-build, which must not be removed with the container
+const StockImageLabel = "dock-route.stock-image"
+
 type ContainerInfo struct {
 	ID     string
 	Name   string
 	Image  string
 	Status string
 	Ports  string
+	// HostPort is the port the container is assigned, also while stopped
+	HostPort string
 }
 
 func (c *Client) ListManagedContainers(ctx context.Context) ([]ContainerInfo, error) {
@@ -49,11 +58,16 @@ func (c *Client) ListManagedContainers(ctx context.Context) ([]ContainerInfo, er
 			Status: container.Status,
 			Ports:  portStr,
 		})
+		if ports := publishedPorts(container); len(ports) > 0 {
+			result[len(result)-1].HostPort = ports[0]
+		}
 	}
 
 	return result, nil
 }
 
+// RemoveContainer removes a container and returns the image it was built
+// from, or an empty string when the image is shared and should be kept
 func (c *Client) RemoveContainer(ctx context.Context, containerName string, force bool) (string, error) {
 	// Find the container
 	containers, err := c.cli.ContainerList(ctx, container.ListOptions{
@@ -71,6 +85,11 @@ func (c *Client) RemoveContainer(ctx context.Context, containerName string, forc
 	containerInfo := containers[0]
 	imageName := containerInfo.Image
 
+	// Stock images are shared with other containers, so there is nothing to clean up
+	if containerInfo.Labels[StockImageLabel] == "true" {
+		imageName = ""
+	}
+
 	// Remove the container
 	err = c.cli.ContainerRemove(ctx, containerInfo.ID, container.RemoveOptions{
 		Force: force,
@@ -82,6 +101,16 @@ func (c *Client) RemoveContainer(ctx context.Context, containerName string, forc
 	return imageName, nil
 }
 
+// IsRunning reports whether a container exists and is running
+func (c *Client) IsRunning(ctx context.Context, containerName string) (bool, error) {
+	containerJSON, err := c.cli.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	return containerJSON.State != nil && containerJSON.State.Running, nil
+}
+
 func (c *Client) GetContainerStatus(ctx context.Context, containerName string) (string, error) {
 	containers, err := c.cli.ContainerList(ctx, container.ListOptions{
 		All:     true,
@@ -97,3 +126,51 @@ func (c *Client) GetContainerStatus(ctx context.Context, containerName string) (
 
 	return containers[0].Status, nil
 }
+
+// PreviewRoutes maps the subdomains of running managed containers to the
+// URLs they are currently published on
+func (c *Client) PreviewRoutes(ctx context.Context) (map[string]string, error) {
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("label", "managed-by=dock-route"),
+			filters.Arg("label", SubdomainLabel),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	routes := make(map[string]string)
+	for _, container := range containers {
+		for _, port := range container.Ports {
+			if port.PublicPort != 0 {
+				routes[container.Labels[SubdomainLabel]] = fmt.Sprintf("http://localhost:%d", port.PublicPort)
+				break
+			}
+		}
+	}
+
+	return routes, nil
+}
+
+// PreviewContainers maps the subdomains of managed containers, running or
+// stopped, to their container names
+func (c *Client) PreviewContainers(ctx context.Context) (map[string]string, error) {
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", "managed-by=dock-route"),
+			filters.Arg("label", SubdomainLabel),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	names := make(map[string]string)
+	for _, container := range containers {
+		names[container.Labels[SubdomainLabel]] = strings.TrimPrefix(container.Names[0], "/")
+	}
+
+	return names, nil
+}
@@ -0,0 +1,86 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrNotReady is returned by WaitForReady when the container is still
+// running but the app didn't answer within the timeout
+var ErrNotReady = errors.New("app not ready")
+
+// WaitForReady polls url until the app answers, the container stops or the
+// timeout passes. A stopped container fails fast instead of waiting it out.
+func (c *Client) WaitForReady(ctx context.Context, containerName string, url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		containerJSON, err := c.cli.ContainerInspect(ctx, containerName)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("%w after %s: %v", ErrNotReady, timeout, lastErr)
+			}
+			return fmt.Errorf("failed to inspect container: %w", err)
+		}
+		if containerJSON.State != nil && !containerJSON.State.Running {
+			return fmt.Errorf("container exited with code %d; check 'dock-route logs %s'", containerJSON.State.ExitCode, containerName)
+		}
+
+		if lastErr = ProbeHTTP(ctx, url); lastErr == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w after %s: %v", ErrNotReady, timeout, lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProbeHTTP reports whether url answers with a non-5xx response
+func ProbeHTTP(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("app responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+// PreviewURL returns the localhost URL a managed container is published on
+func (c *Client) PreviewURL(ctx context.Context, containerName string) (string, error) {
+	info, err := c.GetContainerInfo(ctx, containerName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, port := range info.Ports {
+		if port.PublicPort != 0 {
+			return fmt.Sprintf("http://localhost:%d", port.PublicPort), nil
+		}
+	}
+
+	return "", fmt.Errorf("container '%s' has no published port", containerName)
+}
@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+)
+
+// ProjectPath returns the host directory bind-mounted at mountPath in the container
+func (c *Client) ProjectPath(ctx context.Context, containerName string, mountPath string) (string, error) {
+	info, err := c.GetContainerInfo(ctx, containerName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, m := range info.Mounts {
+		if m.Type == "bind" && m.Destination == mountPath {
+			return m.Source, nil
+		}
+	}
+
+	return "", fmt.Errorf("container '%s' has no project bind mount at %s", containerName, mountPath)
+}
+
+// InstalledVersion returns the version of a package installed in the container's node_modules
+func (c *Client) InstalledVersion(ctx context.Context, containerName string, workingDir string, pkg string) (string, error) {
+	info, err := c.GetContainerInfo(ctx, containerName)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := c.readFileFromContainer(ctx, info.ID, path.Join(workingDir, "node_modules", pkg, "package.json"))
+	if err != nil {
+		return "", err
+	}
+
+	var manifest struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return "", fmt.Errorf("failed to parse package.json for %s: %w", pkg, err)
+	}
+
+	return manifest.Version, nil
+}
+
+func (c *Client) readFileFromContainer(ctx context.Context, containerID, srcPath string) ([]byte, error) {
+	reader, _, err := c.cli.CopyFromContainer(ctx, containerID, srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy from container: %w", err)
+	}
+	defer reader.Close()
+
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar: %w", err)
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			return io.ReadAll(tr)
+		}
+	}
+
+	return nil, fmt.Errorf("file not found in tar archive")
+}
@@ -0,0 +1,110 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// HostPortLabel records the host port a container was assigned so redeploys
+// keep the same preview URL
+const HostPortLabel = "dock-route.host-port"
+
+// Host ports tried, in order, when none is requested
+const (
+	firstHostPort = 8081
+	lastHostPort  = 8999
+)
+
+// AllocateHostPort returns the host port to publish a container on. A
+// requested port is checked for conflicts; otherwise the container keeps the
+// port it had before, or gets the first free one.
+func (c *Client) AllocateHostPort(ctx context.Context, containerName string, hostIP string, requested string) (string, error) {
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", "managed-by=dock-route")),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	// Ports of other managed containers are taken even while those are stopped
+	reserved := make(map[string]string)
+	var current string
+	for _, ctr := range containers {
+		name := strings.TrimPrefix(ctr.Names[0], "/")
+
+		ports := publishedPorts(ctr)
+		if name == containerName {
+			if len(ports) > 0 {
+				current = ports[0]
+			}
+			continue
+		}
+		for _, port := range ports {
+			reserved[port] = name
+		}
+	}
+
+	if requested != "" {
+		if _, err := strconv.Atoi(requested); err != nil {
+			return "", fmt.Errorf("invalid host port: %s", requested)
+		}
+		if owner, taken := reserved[requested]; taken {
+			return "", fmt.Errorf("host port %s is already used by container '%s'", requested, owner)
+		}
+		// The container being replaced releases its own port
+		if requested != current && !portFree(hostIP, requested) {
+			return "", fmt.Errorf("host port %s is already in use", requested)
+		}
+		return requested, nil
+	}
+
+	if current != "" {
+		if _, taken := reserved[current]; !taken {
+			return current, nil
+		}
+	}
+
+	for p := firstHostPort; p <= lastHostPort; p++ {
+		port := strconv.Itoa(p)
+		if _, taken := reserved[port]; taken {
+			continue
+		}
+		if portFree(hostIP, port) {
+			return port, nil
+		}
+	}
+
+	return "", fmt.Errorf("no free host port between %d and %d", firstHostPort, lastHostPort)
+}
+
+// publishedPorts returns the host ports a container is assigned, from its
+// label when set and its port bindings otherwise
+func publishedPorts(ctr container.Summary) []string {
+	if port := ctr.Labels[HostPortLabel]; port != "" {
+		return []string{port}
+	}
+
+	var ports []string
+	for _, port := range ctr.Ports {
+		if port.PublicPort != 0 {
+			ports = append(ports, strconv.Itoa(int(port.PublicPort)))
+		}
+	}
+	return ports
+}
+
+func portFree(hostIP string, port string) bool {
+	listener, err := net.Listen("tcp", net.JoinHostPort(hostIP, port))
+	if err != nil {
+		return false
+	}
+	listener.Close()
+	return true
+}
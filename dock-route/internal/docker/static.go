@@ -0,0 +1,107 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/lahiruramesh/dock-route/internal/config"
+)
+
+// BuildStatic runs the template's production build in a throwaway container
+// and returns the host directory holding the exported site.
+func (c *Client) BuildStatic(ctx context.Context, config *config.DeployConfig) (string, error) {
+	tmpl := config.Template
+	if tmpl.DevImage == "" || len(tmpl.BuildCommand) == 0 || tmpl.StaticDir == "" {
+		return "", fmt.Errorf("template %s does not support static export", tmpl.Name)
+	}
+
+	sourcePath, err := filepath.Abs(config.SourcePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve source path: %w", err)
+	}
+
+	exists, err := c.ImageExists(ctx, tmpl.DevImage)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		log.Printf("Pulling image '%s'...", tmpl.DevImage)
+		if err := c.PullImage(ctx, tmpl.DevImage); err != nil {
+			return "", err
+		}
+	}
+
+	log.Printf("Building static export for '%s'...", config.ContainerName)
+
+	// NODE_ENV=production only for the build; during install it would skip
+	// devDependencies such as vite and typescript
+	build := append([]string{"env", "NODE_ENV=production"}, tmpl.BuildCommand...)
+
+	resp, err := c.cli.ContainerCreate(ctx, &container.Config{
+		Image:      tmpl.DevImage,
+		Cmd:        []string{"sh", "-c", installAndExec(tmpl.Install, build)},
+		WorkingDir: tmpl.MountPath,
+		Labels: map[string]string{
+			"managed-by": "dock-route",
+			"mode":       "static-build",
+		},
+	}, &container.HostConfig{
+		Mounts: []mount.Mount{
+			{
+				Type:   mount.TypeBind,
+				Source: sourcePath,
+				Target: tmpl.MountPath,
+			},
+			// Anonymous volume so the build never touches the dev container's node_modules
+			{
+				Type:   mount.TypeVolume,
+				Target: path.Join(tmpl.MountPath, "node_modules"),
+			},
+		},
+	}, nil, nil, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to create build container: %w", err)
+	}
+	defer c.cli.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true, RemoveVolumes: true})
+
+	statusCh, errCh := c.cli.ContainerWait(ctx, resp.ID, container.WaitConditionNextExit)
+
+	if err := c.cli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return "", fmt.Errorf("failed to start build container: %w", err)
+	}
+
+	logs, err := c.cli.ContainerLogs(ctx, resp.ID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get build logs: %w", err)
+	}
+	defer logs.Close()
+	stdcopy.StdCopy(os.Stdout, os.Stderr, logs)
+
+	select {
+	case err := <-errCh:
+		return "", fmt.Errorf("failed waiting for build: %w", err)
+	case status := <-statusCh:
+		if status.StatusCode != 0 {
+			return "", fmt.Errorf("build exited with code %d", status.StatusCode)
+		}
+	}
+
+	outputDir := filepath.Join(sourcePath, tmpl.StaticDir)
+	if _, err := os.Stat(filepath.Join(outputDir, "index.html")); err != nil {
+		return "", fmt.Errorf("build produced no %s/index.html; is static export enabled for this project?", tmpl.StaticDir)
+	}
+
+	log.Printf("Static export ready in %s", outputDir)
+	return outputDir, nil
+}
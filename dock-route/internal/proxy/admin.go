@@ -0,0 +1,153 @@
+package proxy
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "log"
+    "net/http"
+    "net/url"
+    "sort"
+    "strings"
+    "time"
+)
+
+// Route is a subdomain mapping as exchanged over the admin API and saved
+// in the proxy state file.
+type Route struct {
+    Subdomain string `json:"subdomain" yaml:"subdomain"`
+    Target    string `json:"target" yaml:"target"`
+}
+
+// AdminHandler exposes route management over HTTP:
+//
+//  GET    /routes                    list routes
+//  POST   /routes                    add or replace a route
+//  DELETE /routes/{subdomain}        remove a route
+//  POST   /routes/{subdomain}/touch  record activity so an idle container isn't stopped
+func (pm *Manager) AdminHandler() http.Handler {
+    mux := http.NewServeMux()
+    
+    mux.HandleFunc("GET /routes", func(w http.ResponseWriter, r *http.Request) {
+        routes := make([]Route, 0)
+        for subdomain, target := range pm.Routes() {
+            routes = append(routes, Route{Subdomain: subdomain, Target: target})
+        }
+        sort.Slice(routes, func(i, j int) bool { return routes[i].Subdomain < routes[j].Subdomain })
+        
+        writeJSON(w, http.StatusOK, map[string]interface{}{"routes": routes})
+    })
+    
+    mux.HandleFunc("POST /routes", func(w http.ResponseWriter, r *http.Request) {
+        var route Route
+        if err := json.NewDecoder(r.Body).Decode(&route); err != nil {
+            writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+            return
+        }
+        if route.Subdomain == "" || strings.Contains(route.Subdomain, ".") || route.Target == "" {
+            writeJSON(w, http.StatusBadRequest, map[string]string{"error": "subdomain and target are required"})
+            return
+        }
+        if target, err := url.Parse(route.Target); err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+            writeJSON(w, http.StatusBadRequest, map[string]string{"error": "target must be an absolute http or https URL"})
+            return
+        }
+        
+        if err := pm.AddProxy(route.Subdomain, route.Target); err != nil {
+            writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+            return
+        }
+        
+        writeJSON(w, http.StatusCreated, route)
+    })
+    
+    mux.HandleFunc("DELETE /routes/{subdomain}", func(w http.ResponseWriter, r *http.Request) {
+        subdomain := r.PathValue("subdomain")
+        if !pm.HasProxy(subdomain) {
+            writeJSON(w, http.StatusNotFound, map[string]string{"error": "route not found"})
+            return
+        }
+        
+        pm.RemoveProxy(subdomain)
+        w.WriteHeader(http.StatusNoContent)
+    })
+    
+    mux.HandleFunc("POST /routes/{subdomain}/touch", func(w http.ResponseWriter, r *http.Request) {
+        subdomain := r.PathValue("subdomain")
+        if !pm.HasProxy(subdomain) {
+            writeJSON(w, http.StatusNotFound, map[string]string{"error": "route not found"})
+            return
+        }
+        
+        pm.Touch(subdomain)
+        w.WriteHeader(http.StatusNoContent)
+    })
+    
+    return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    if err := json.NewEncoder(w).Encode(v); err != nil {
+        log.Printf("Failed to write admin response: %v", err)
+    }
+}
+
+var adminClient = &http.Client{Timeout: 10 * time.Second}
+
+// RegisterRoute adds a route on a running proxy through its admin API.
+func RegisterRoute(adminURL, subdomain, targetURL string) error {
+    body, err := json.Marshal(Route{Subdomain: subdomain, Target: targetURL})
+    if err != nil {
+        return err
+    }
+    
+    resp, err := adminClient.Post(strings.TrimRight(adminURL, "/")+"/routes", "application/json", bytes.NewReader(body))
+    if err != nil {
+        return fmt.Errorf("failed to reach proxy admin API: %w", err)
+    }
+    defer resp.Body.Close()
+    
+    if resp.StatusCode != http.StatusCreated {
+        return fmt.Errorf("proxy admin API returned %s", resp.Status)
+    }
+    
+    return nil
+}
+
+// UnregisterRoute removes a route from a running proxy through its admin API.
+func UnregisterRoute(adminURL, subdomain string) error {
+    req, err := http.NewRequest(http.MethodDelete, strings.TrimRight(adminURL, "/")+"/routes/"+url.PathEscape(subdomain), nil)
+    if err != nil {
+        return err
+    }
+    
+    resp, err := adminClient.Do(req)
+    if err != nil {
+        return fmt.Errorf("failed to reach proxy admin API: %w", err)
+    }
+    defer resp.Body.Close()
+    
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+        return fmt.Errorf("proxy admin API returned %s", resp.Status)
+    }
+    
+    return nil
+}
+
+// TouchRoute reports activity for a subdomain on a running proxy, such as
+// file edits or chat, so its container isn't stopped as idle.
+func TouchRoute(adminURL, subdomain string) error {
+    resp, err := adminClient.Post(strings.TrimRight(adminURL, "/")+"/routes/"+url.PathEscape(subdomain)+"/touch", "application/json", nil)
+    if err != nil {
+        return fmt.Errorf("failed to reach proxy admin API: %w", err)
+    }
+    defer resp.Body.Close()
+    
+    if resp.StatusCode != http.StatusNoContent {
+        return fmt.Errorf("proxy admin API returned %s", resp.Status)
+    }
+    
+    return nil
+}
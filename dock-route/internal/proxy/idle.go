@@ -0,0 +1,141 @@
+package proxy
+
+import (
+    "context"
+    "log"
+    "sync"
+    "time"
+)
+
+// ContainerController starts and stops the container behind a subdomain.
+// docker.Client satisfies it.
+type ContainerController interface {
+    IsRunning(ctx context.Context, containerName string) (bool, error)
+    StartContainer(ctx context.Context, containerName string) error
+    StopContainer(ctx context.Context, containerName string) error
+}
+
+// IdleReaper stops containers whose subdomain has not been accessed within
+// the TTL and starts them again on the next request.
+type IdleReaper struct {
+    manager    *Manager
+    controller ContainerController
+    ttl        time.Duration
+
+    mu         sync.Mutex
+    containers map[string]string // subdomain -> container name
+    sleeping   map[string]bool
+    starting   map[string]bool
+}
+
+func NewIdleReaper(manager *Manager, controller ContainerController, ttl time.Duration) *IdleReaper {
+    r := &IdleReaper{
+        manager:    manager,
+        controller: controller,
+        ttl:        ttl,
+        containers: make(map[string]string),
+        sleeping:   make(map[string]bool),
+        starting:   make(map[string]bool),
+    }
+    manager.SetWakeFunc(r.wake)
+    return r
+}
+
+// Track registers the container that serves a subdomain.
+func (r *IdleReaper) Track(subdomain, containerName string) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    r.containers[subdomain] = containerName
+}
+
+// Run checks for idle subdomains every interval until ctx is cancelled.
+func (r *IdleReaper) Run(ctx context.Context, interval time.Duration) {
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            r.reap(ctx)
+        }
+    }
+}
+
+// reap stops idle containers. Docker calls happen without holding r.mu so
+// requests for other subdomains aren't blocked while a container stops.
+func (r *IdleReaper) reap(ctx context.Context) {
+    idle := make(map[string]string)
+
+    r.mu.Lock()
+    for subdomain, containerName := range r.containers {
+        if r.sleeping[subdomain] || r.starting[subdomain] {
+            continue
+        }
+
+        lastAccess, ok := r.manager.LastAccess(subdomain)
+        if ok && time.Since(lastAccess) >= r.ttl {
+            idle[subdomain] = containerName
+        }
+    }
+    r.mu.Unlock()
+
+    for subdomain, containerName := range idle {
+        running, err := r.controller.IsRunning(ctx, containerName)
+        if err != nil {
+            log.Printf("Warning: failed to inspect idle container '%s': %v", containerName, err)
+            continue
+        }
+
+        // A container stopped elsewhere only needs waking on the next request
+        if running {
+            log.Printf("Stopping idle container '%s'", containerName)
+            if err := r.controller.StopContainer(ctx, containerName); err != nil {
+                log.Printf("Warning: failed to stop idle container '%s': %v", containerName, err)
+                continue
+            }
+        }
+
+        r.mu.Lock()
+        r.sleeping[subdomain] = true
+        r.mu.Unlock()
+    }
+}
+
+// wake starts a sleeping container in the background and reports whether
+// the request should get the warming up page, so no request waits on Docker.
+func (r *IdleReaper) wake(subdomain string) bool {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if !r.sleeping[subdomain] {
+        return false
+    }
+    if !r.starting[subdomain] {
+        r.starting[subdomain] = true
+        go r.start(subdomain, r.containers[subdomain])
+    }
+    return true
+}
+
+// start brings a sleeping container back, unless it was started elsewhere
+func (r *IdleReaper) start(subdomain, containerName string) {
+    ctx := context.Background()
+    running, err := r.controller.IsRunning(ctx, containerName)
+    if err == nil && !running {
+        log.Printf("Restarting idle container '%s' for subdomain %s", containerName, subdomain)
+        err = r.controller.StartContainer(ctx, containerName)
+    }
+
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    delete(r.starting, subdomain)
+    if err != nil {
+        log.Printf("Warning: failed to restart container '%s': %v", containerName, err)
+        return
+    }
+    delete(r.sleeping, subdomain)
+}
@@ -0,0 +1,86 @@
+package proxy
+
+import (
+    "bytes"
+    "fmt"
+    "image"
+    "image/color"
+    "image/png"
+    "log"
+    "net"
+    "net/http"
+
+    "github.com/makiuchi-d/gozxing"
+    "github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// QRCodePath is where the proxy serves the QR code for the LAN preview URL.
+const QRCodePath = "/_dock-route/qr.png"
+
+// LANAddress returns the first private IPv4 address of this host.
+func LANAddress() (string, error) {
+    addrs, err := net.InterfaceAddrs()
+    if err != nil {
+        return "", fmt.Errorf("failed to list interface addresses: %w", err)
+    }
+    
+    for _, addr := range addrs {
+        ipNet, ok := addr.(*net.IPNet)
+        if !ok || ipNet.IP.IsLoopback() {
+            continue
+        }
+        if ip := ipNet.IP.To4(); ip != nil && ip.IsPrivate() {
+            return ip.String(), nil
+        }
+    }
+    
+    return "", fmt.Errorf("no private IPv4 address found")
+}
+
+// WithQRCode serves a PNG QR code for previewURL at QRCodePath and passes
+// every other request to next.
+func WithQRCode(next http.Handler, previewURL string) (http.Handler, error) {
+    image, err := qrCodePNG(previewURL, 8)
+    if err != nil {
+        return nil, err
+    }
+    
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if r.URL.Path != QRCodePath {
+            next.ServeHTTP(w, r)
+            return
+        }
+        
+        log.Printf("Serving QR code for %s", previewURL)
+        w.Header().Set("Content-Type", "image/png")
+        w.Header().Set("Cache-Control", "no-store")
+        w.Write(image)
+    }), nil
+}
+
+// qrCodePNG renders text as a QR code PNG with scale pixels per module.
+func qrCodePNG(text string, scale int) ([]byte, error) {
+    // A size of 0 yields one pixel per module, quiet zone included
+    matrix, err := qrcode.NewQRCodeWriter().Encode(text, gozxing.BarcodeFormat_QR_CODE, 0, 0, nil)
+    if err != nil {
+        return nil, fmt.Errorf("failed to encode QR code: %w", err)
+    }
+    
+    width, height := matrix.GetWidth(), matrix.GetHeight()
+    img := image.NewGray(image.Rect(0, 0, width*scale, height*scale))
+    for y := 0; y < height*scale; y++ {
+        for x := 0; x < width*scale; x++ {
+            if matrix.Get(x/scale, y/scale) {
+                img.SetGray(x, y, color.Gray{Y: 0})
+            } else {
+                img.SetGray(x, y, color.Gray{Y: 255})
+            }
+        }
+    }
+    
+    var buf bytes.Buffer
+    if err := png.Encode(&buf, img); err != nil {
+        return nil, fmt.Errorf("failed to encode PNG: %w", err)
+    }
+    return buf.Bytes(), nil
+}
@@ -0,0 +1,89 @@
+package proxy
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// previewURL returns a URL-like text of exactly n bytes, like the LAN
+// preview URLs dock-route encodes.
+func previewURL(n int) string {
+	url := "http://192.168.1.20:8081/"
+	if n <= len(url) {
+		return url[:n]
+	}
+	return url + strings.Repeat("a", n-len(url))
+}
+
+func decodeQRCode(t *testing.T, data []byte) string {
+	t.Helper()
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	bmp, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		t.Fatalf("NewBinaryBitmapFromImage() error = %v", err)
+	}
+	result, err := qrcode.NewQRCodeReader().Decode(bmp, nil)
+	if err != nil {
+		t.Fatalf("decode error = %v", err)
+	}
+	return result.GetText()
+}
+
+func TestQRCodePNGRoundTrip(t *testing.T) {
+	// Short LAN URLs up to ones longer than the old 134 byte limit
+	for _, length := range []int{17, 24, 53, 134, 300} {
+		text := previewURL(length)
+
+		data, err := qrCodePNG(text, 4)
+		if err != nil {
+			t.Fatalf("%d bytes: qrCodePNG() error = %v", length, err)
+		}
+		if got := decodeQRCode(t, data); got != text {
+			t.Errorf("%d bytes: decoded %q, want %q", length, got, text)
+		}
+	}
+}
+
+func TestWithQRCode(t *testing.T) {
+	const url = "http://192.168.1.20:8081"
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	handler, err := WithQRCode(next, url)
+	if err != nil {
+		t.Fatalf("WithQRCode() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, QRCodePath, nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+		t.Errorf("Content-Type = %q, want image/png", ct)
+	}
+	if got := decodeQRCode(t, rec.Body.Bytes()); got != url {
+		t.Errorf("decoded %q, want %q", got, url)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("other paths: status = %d, want %d", rec.Code, http.StatusTeapot)
+	}
+}
+
+func TestQRCodePNGEmpty(t *testing.T) {
+	if _, err := qrCodePNG("", 4); err == nil {
+		t.Error("qrCodePNG(\"\") succeeded, want error")
+	}
+}
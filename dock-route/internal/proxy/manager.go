@@ -6,21 +6,44 @@ import (
     "net/http"
     "net/http/httputil"
     "net/url"
+    "os"
+    "path"
+    "path/filepath"
     "strings"
     "sync"
+    "time"
 )
 
+// WakeFunc is called before a request is proxied. Returning true means the
+// backend was asleep and is now warming up, so the request is answered with
+// a "warming up" page instead of being forwarded.
+type WakeFunc func(subdomain string) bool
+
 type Manager struct {
-    mu      sync.RWMutex
-    proxies map[string]*httputil.ReverseProxy
+    mu         sync.RWMutex
+    proxies    map[string]http.Handler
+    targets    map[string]string
+    lastAccess map[string]time.Time
+    wake       WakeFunc
+    statePath  string // routes are saved here when set, see Persist
 }
 
 func NewManager() *Manager {
     return &Manager{
-        proxies: make(map[string]*httputil.ReverseProxy),
+        proxies:    make(map[string]http.Handler),
+        targets:    make(map[string]string),
+        lastAccess: make(map[string]time.Time),
     }
 }
 
+// SetWakeFunc installs the hook used to restart sleeping backends.
+func (pm *Manager) SetWakeFunc(wake WakeFunc) {
+    pm.mu.Lock()
+    defer pm.mu.Unlock()
+
+    pm.wake = wake
+}
+
 func (pm *Manager) AddProxy(subdomain string, targetURL string) error {
     pm.mu.Lock()
     defer pm.mu.Unlock()
@@ -30,6 +53,11 @@ func (pm *Manager) AddProxy(subdomain string, targetURL string) error {
         return fmt.Errorf("invalid target URL: %w", err)
     }
     
+    // Static sites go through AddStaticSite so they can't be added remotely
+    if target.Scheme == "file" {
+        return fmt.Errorf("file targets can only be added as static sites")
+    }
+    
     proxy := httputil.NewSingleHostReverseProxy(target)
     
     // Custom director
@@ -42,7 +70,32 @@ func (pm *Manager) AddProxy(subdomain string, targetURL string) error {
     }
     
     pm.proxies[subdomain] = proxy
+    pm.targets[subdomain] = targetURL
+    pm.lastAccess[subdomain] = time.Now()
     log.Printf("Added proxy for subdomain: %s -> %s", subdomain, targetURL)
+    pm.persistLocked()
+    
+    return nil
+}
+
+// AddStaticSite serves a static export from dir. The directory must be
+// absolute and hold an index.html, so it can't expose arbitrary paths.
+func (pm *Manager) AddStaticSite(subdomain string, dir string) error {
+    if !filepath.IsAbs(dir) {
+        return fmt.Errorf("static site directory must be absolute: %s", dir)
+    }
+    if _, err := os.Stat(filepath.Join(dir, "index.html")); err != nil {
+        return fmt.Errorf("static site directory has no index.html: %s", dir)
+    }
+    
+    pm.mu.Lock()
+    defer pm.mu.Unlock()
+    
+    pm.proxies[subdomain] = staticHandler(dir)
+    pm.targets[subdomain] = (&url.URL{Scheme: "file", Path: dir}).String()
+    pm.lastAccess[subdomain] = time.Now()
+    log.Printf("Added static site for subdomain: %s -> %s", subdomain, dir)
+    pm.persistLocked()
     
     return nil
 }
@@ -58,9 +111,13 @@ func (pm *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
         subdomain = "default"
     }
     
-    pm.mu.RLock()
+    pm.mu.Lock()
     proxy, found := pm.proxies[subdomain]
-    pm.mu.RUnlock()
+    if found {
+        pm.lastAccess[subdomain] = time.Now()
+    }
+    wake := pm.wake
+    pm.mu.Unlock()
     
     if !found {
         log.Printf("No proxy found for subdomain: %s (Host: %s)", subdomain, host)
@@ -68,6 +125,12 @@ func (pm *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
         return
     }
     
+    if wake != nil && wake(subdomain) {
+        log.Printf("Subdomain %s is warming up", subdomain)
+        writeWarmingUp(w)
+        return
+    }
+    
     log.Printf("Proxying request for %s to target for subdomain %s", r.URL.String(), subdomain)
     proxy.ServeHTTP(w, r)
 }
@@ -77,7 +140,10 @@ func (pm *Manager) RemoveProxy(subdomain string) {
     defer pm.mu.Unlock()
     
     delete(pm.proxies, subdomain)
+    delete(pm.targets, subdomain)
+    delete(pm.lastAccess, subdomain)
     log.Printf("Removed proxy for subdomain: %s", subdomain)
+    pm.persistLocked()
 }
 
 func (pm *Manager) GetActiveSubdomains() []string {
@@ -92,6 +158,19 @@ func (pm *Manager) GetActiveSubdomains() []string {
     return subdomains
 }
 
+// Routes returns a copy of the subdomain to target URL mappings.
+func (pm *Manager) Routes() map[string]string {
+    pm.mu.RLock()
+    defer pm.mu.RUnlock()
+    
+    routes := make(map[string]string, len(pm.targets))
+    for subdomain, target := range pm.targets {
+        routes[subdomain] = target
+    }
+    
+    return routes
+}
+
 func (pm *Manager) HasProxy(subdomain string) bool {
     pm.mu.RLock()
     defer pm.mu.RUnlock()
@@ -99,3 +178,46 @@ func (pm *Manager) HasProxy(subdomain string) bool {
     _, exists := pm.proxies[subdomain]
     return exists
 }
+
+// LastAccess returns the time of the most recent request for a subdomain.
+func (pm *Manager) LastAccess(subdomain string) (time.Time, bool) {
+    pm.mu.RLock()
+    defer pm.mu.RUnlock()
+    
+    t, exists := pm.lastAccess[subdomain]
+    return t, exists
+}
+
+// Touch marks a subdomain as recently used without proxying a request. The
+// admin API exposes it so activity outside the proxy keeps previews awake.
+func (pm *Manager) Touch(subdomain string) {
+    pm.mu.Lock()
+    defer pm.mu.Unlock()
+    
+    if _, exists := pm.proxies[subdomain]; exists {
+        pm.lastAccess[subdomain] = time.Now()
+    }
+}
+
+func writeWarmingUp(w http.ResponseWriter) {
+    w.Header().Set("Content-Type", "text/html; charset=utf-8")
+    w.Header().Set("Retry-After", "5")
+    w.WriteHeader(http.StatusServiceUnavailable)
+    fmt.Fprint(w, `<!DOCTYPE html><html><head><meta http-equiv="refresh" content="5"><title>Warming up</title></head>`+
+        `<body><p>Warming up: the preview was stopped after being idle and is restarting. This page will reload automatically.</p></body></html>`)
+}
+
+// staticHandler serves files from dir, falling back to index.html so
+// client-side routes of single page apps resolve.
+func staticHandler(dir string) http.Handler {
+    files := http.FileServer(http.Dir(dir))
+    
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        name := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+r.URL.Path)))
+        if _, err := os.Stat(name); os.IsNotExist(err) {
+            http.ServeFile(w, r, filepath.Join(dir, "index.html"))
+            return
+        }
+        files.ServeHTTP(w, r)
+    })
+}
@@ -40,6 +40,11 @@ func (s *Server) RemoveProxy(subdomain string) {
     s.manager.RemoveProxy(subdomain)
 }
 
+// Manager returns the route table the server proxies to.
+func (s *Server) Manager() *Manager {
+    return s.manager
+}
+
 func (s *Server) Start() error {
     log.Printf("Starting reverse proxy server on port %s", s.port)
     
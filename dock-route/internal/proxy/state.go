@@ -0,0 +1,125 @@
+package proxy
+
+import (
+    "fmt"
+    "log"
+    "net/url"
+    "os"
+    "path/filepath"
+    "sort"
+
+    "github.com/lahiruramesh/dock-route/internal/fsutil"
+    "gopkg.in/yaml.v3"
+)
+
+// LoadRoutes reads routes saved by a persistent Manager. A missing file
+// means no routes.
+func LoadRoutes(path string) ([]Route, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil, nil
+        }
+        return nil, fmt.Errorf("failed to read proxy state: %w", err)
+    }
+
+    var routes []Route
+    if err := yaml.Unmarshal(data, &routes); err != nil {
+        return nil, fmt.Errorf("failed to parse proxy state: %w", err)
+    }
+
+    return routes, nil
+}
+
+// ForgetRoute drops a subdomain from the state file so the next proxy run
+// does not restore it. It reports whether the subdomain was saved.
+func ForgetRoute(path, subdomain string) (bool, error) {
+    routes, err := LoadRoutes(path)
+    if err != nil || routes == nil {
+        return false, err
+    }
+
+    kept := routes[:0]
+    for _, route := range routes {
+        if route.Subdomain != subdomain {
+            kept = append(kept, route)
+        }
+    }
+    if len(kept) == len(routes) {
+        return false, nil
+    }
+
+    data, err := yaml.Marshal(kept)
+    if err != nil {
+        return false, fmt.Errorf("failed to encode proxy state: %w", err)
+    }
+
+    if err := fsutil.WriteFileAtomic(path, data, 0644); err != nil {
+        return false, fmt.Errorf("failed to write proxy state: %w", err)
+    }
+
+    return true, nil
+}
+
+// Persist restores the routes saved at path and saves the route table there
+// whenever it changes, so a restarted proxy serves the same subdomains.
+func (pm *Manager) Persist(path string) error {
+    routes, err := LoadRoutes(path)
+    if err != nil {
+        return err
+    }
+
+    for _, route := range routes {
+        var err error
+        if target, parseErr := url.Parse(route.Target); parseErr == nil && target.Scheme == "file" {
+            err = pm.AddStaticSite(route.Subdomain, target.Path)
+        } else {
+            err = pm.AddProxy(route.Subdomain, route.Target)
+        }
+        if err != nil {
+            log.Printf("Warning: skipping saved route %s: %v", route.Subdomain, err)
+        }
+    }
+
+    if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+        return fmt.Errorf("failed to create proxy state directory: %w", err)
+    }
+
+    pm.mu.Lock()
+    defer pm.mu.Unlock()
+
+    pm.statePath = path
+    return pm.saveLocked()
+}
+
+// saveLocked writes the route table to the state file. The caller must hold pm.mu.
+func (pm *Manager) saveLocked() error {
+    if pm.statePath == "" {
+        return nil
+    }
+
+    routes := make([]Route, 0, len(pm.targets))
+    for subdomain, target := range pm.targets {
+        routes = append(routes, Route{Subdomain: subdomain, Target: target})
+    }
+    sort.Slice(routes, func(i, j int) bool { return routes[i].Subdomain < routes[j].Subdomain })
+
+    data, err := yaml.Marshal(routes)
+    if err != nil {
+        return fmt.Errorf("failed to encode proxy state: %w", err)
+    }
+
+    if err := fsutil.WriteFileAtomic(pm.statePath, data, 0644); err != nil {
+        return fmt.Errorf("failed to write proxy state: %w", err)
+    }
+
+    return nil
+}
+
+// persistLocked saves the route table, logging failures since the change is
+// already live. The caller must hold pm.mu.
+func (pm *Manager) persistLocked() {
+    if err := pm.saveLocked(); err != nil {
+        log.Printf("Warning: %v", err)
+    }
+}
@@ -0,0 +1,78 @@
+package templates
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/lahiruramesh/dock-route/internal/fsutil"
+    "github.com/moby/patternmatcher/ignorefile"
+)
+
+// defaultIgnore applies to templates without their own ignore list, such as
+// most templates fetched from git.
+var defaultIgnore = []string{
+    "node_modules",
+    "dist",
+    "build",
+    "coverage",
+    ".env*.local",
+    "*.log",
+    ".DS_Store",
+}
+
+// WriteIgnoreFiles creates .gitignore and .dockerignore in dir from the
+// template's ignore rules, or common Node rules when it has none. Existing
+// files are left untouched; the names of the files that were written are
+// returned.
+func (t *Template) WriteIgnoreFiles(dir string) ([]string, error) {
+    ignore := t.Ignore
+    if len(ignore) == 0 {
+        ignore = defaultIgnore
+    }
+    
+    files := map[string][]string{
+        ".gitignore":    ignore,
+        ".dockerignore": append([]string{".git", ".gitignore", ".dockerignore"}, ignore...),
+    }
+    
+    var written []string
+    for _, name := range []string{".gitignore", ".dockerignore"} {
+        path := filepath.Join(dir, name)
+        if _, err := os.Stat(path); err == nil {
+            continue
+        } else if !os.IsNotExist(err) {
+            return written, fmt.Errorf("failed to check %s: %w", name, err)
+        }
+        
+        content := fmt.Sprintf("# Generated by dock-route for %s projects\n%s\n", t.Name, strings.Join(files[name], "\n"))
+        if err := fsutil.WriteFileAtomic(path, []byte(content), 0644); err != nil {
+            return written, fmt.Errorf("failed to write %s: %w", name, err)
+        }
+        written = append(written, name)
+    }
+    
+    return written, nil
+}
+
+// ReadIgnoreFile returns the patterns in a .dockerignore file as Docker
+// reads them: comments and blank lines are dropped, paths are cleaned and
+// "!" negations are kept. A missing file yields no patterns.
+func ReadIgnoreFile(path string) ([]string, error) {
+    f, err := os.Open(path)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil, nil
+        }
+        return nil, fmt.Errorf("failed to read %s: %w", path, err)
+    }
+    defer f.Close()
+    
+    patterns, err := ignorefile.ReadAll(f)
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+    }
+    
+    return patterns, nil
+}
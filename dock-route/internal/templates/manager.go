@@ -3,8 +3,12 @@ package templates
 import (
     "embed"
     "fmt"
+    "io/fs"
+    "os"
+    "path"
     "path/filepath"
-    
+    "sort"
+
     "gopkg.in/yaml.v3"
 )
 //go:embed data/*
@@ -12,11 +16,18 @@ var templatesFS embed.FS
 
 type Manager struct {
     templates map[string]*Template
+    dir       string // cache for templates cloned from git, empty if unavailable
 }
 
 func NewManager() *Manager {
+    dir := ""
+    if home, err := os.UserHomeDir(); err == nil {
+        dir = filepath.Join(home, ".dock-route", "templates")
+    }
+
     return &Manager{
         templates: make(map[string]*Template),
+        dir:       dir,
     }
 }
 
@@ -24,48 +35,67 @@ func (m *Manager) GetTemplate(appType string) (*Template, error) {
     if template, exists := m.templates[appType]; exists {
         return template, nil
     }
-    
-    // Load template from embedded filesystem
-    templatePath := filepath.Join("data", appType, "template.yaml")
-    data, err := templatesFS.ReadFile(templatePath)
+
+    // Templates cloned from git take precedence over the built-in ones
+    var source fs.FS
+    if m.isRemote(appType) {
+        source = os.DirFS(filepath.Join(m.dir, appType))
+    } else {
+        sub, err := fs.Sub(templatesFS, path.Join("data", appType))
+        if err != nil {
+            return nil, fmt.Errorf("template not found for app type: %s", appType)
+        }
+        source = sub
+    }
+
+    data, err := fs.ReadFile(source, "template.yaml")
     if err != nil {
 		fmt.Println(err)
         return nil, fmt.Errorf("template not found for app type: %s", appType)
     }
-    
+
     var template Template
     if err := yaml.Unmarshal(data, &template); err != nil {
         return nil, fmt.Errorf("failed to parse template: %w", err)
     }
-    
+
     // Load Dockerfile content
-    dockerfilePath := filepath.Join("data", appType, "Dockerfile")
-    dockerfileContent, err := templatesFS.ReadFile(dockerfilePath)
+    dockerfileContent, err := fs.ReadFile(source, "Dockerfile")
     if err != nil {
         return nil, fmt.Errorf("failed to load Dockerfile: %w", err)
     }
-    
+
     template.Dockerfile = string(dockerfileContent)
-    
+
     // Cache the template
     m.templates[appType] = &template
-    
+
     return &template, nil
 }
 
 func (m *Manager) ListTemplates() []string {
-    var types []string
-    
+    seen := make(map[string]bool)
+
     entries, err := templatesFS.ReadDir("data")
-    if err != nil {
-        return types
+    if err == nil {
+        for _, entry := range entries {
+            if entry.IsDir() {
+                seen[entry.Name()] = true
+            }
+        }
     }
-    
-    for _, entry := range entries {
-        if entry.IsDir() {
-            types = append(types, entry.Name())
+
+    if remotes, err := m.Remotes(); err == nil {
+        for _, remote := range remotes {
+            seen[remote.Name] = true
         }
     }
-    
+
+    var types []string
+    for name := range seen {
+        types = append(types, name)
+    }
+    sort.Strings(types)
+
     return types
 }
@@ -0,0 +1,193 @@
+package templates
+
+import (
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strings"
+
+    "github.com/lahiruramesh/dock-route/internal/fsutil"
+    "gopkg.in/yaml.v3"
+)
+
+// RemoteTemplate is a template maintained in a git repository. The
+// repository root holds template.yaml and Dockerfile, like the built-in
+// templates under data/.
+type RemoteTemplate struct {
+    Name string `yaml:"name"`
+    URL  string `yaml:"url"`
+    Ref  string `yaml:"ref,omitempty"` // branch or tag, empty for the default branch
+}
+
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+const registryFile = "registry.yaml"
+
+// Remotes returns the templates registered from git.
+func (m *Manager) Remotes() ([]RemoteTemplate, error) {
+    if m.dir == "" {
+        return nil, nil
+    }
+
+    data, err := os.ReadFile(filepath.Join(m.dir, registryFile))
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil, nil
+        }
+        return nil, fmt.Errorf("failed to read template registry: %w", err)
+    }
+
+    var remotes []RemoteTemplate
+    if err := yaml.Unmarshal(data, &remotes); err != nil {
+        return nil, fmt.Errorf("failed to parse template registry: %w", err)
+    }
+
+    return remotes, nil
+}
+
+// Remote returns the registration for a git template.
+func (m *Manager) Remote(name string) (*RemoteTemplate, error) {
+    remotes, err := m.Remotes()
+    if err != nil {
+        return nil, err
+    }
+
+    for _, remote := range remotes {
+        if remote.Name == name {
+            return &remote, nil
+        }
+    }
+
+    return nil, fmt.Errorf("no git template named %s", name)
+}
+
+// AddRemote clones a template repository into the local cache and registers
+// it. Registering an existing name replaces it.
+func (m *Manager) AddRemote(name, url, ref string) error {
+    if m.dir == "" {
+        return fmt.Errorf("template cache directory is unavailable")
+    }
+    if !templateNamePattern.MatchString(name) {
+        return fmt.Errorf("invalid template name: %s", name)
+    }
+    // Keep url and ref from being read as git options
+    if strings.HasPrefix(url, "-") || url == "" {
+        return fmt.Errorf("invalid repository URL: %s", url)
+    }
+    if strings.HasPrefix(ref, "-") {
+        return fmt.Errorf("invalid ref: %s", ref)
+    }
+
+    if err := os.MkdirAll(m.dir, 0755); err != nil {
+        return fmt.Errorf("failed to create template cache: %w", err)
+    }
+
+    // Clone next to the final location so a failed clone leaves the cache intact
+    staging, err := os.MkdirTemp(m.dir, "."+name+"-")
+    if err != nil {
+        return fmt.Errorf("failed to create staging directory: %w", err)
+    }
+    defer os.RemoveAll(staging)
+
+    args := []string{"clone", "--depth", "1"}
+    if ref != "" {
+        args = append(args, "--branch", ref)
+    }
+    args = append(args, "--", url, staging)
+
+    output, err := exec.Command("git", args...).CombinedOutput()
+    if err != nil {
+        return fmt.Errorf("git clone failed: %w\n%s", err, output)
+    }
+
+    for _, required := range []string{"template.yaml", "Dockerfile"} {
+        if _, err := os.Stat(filepath.Join(staging, required)); err != nil {
+            return fmt.Errorf("repository is missing %s", required)
+        }
+    }
+
+    target := filepath.Join(m.dir, name)
+    if err := os.RemoveAll(target); err != nil {
+        return fmt.Errorf("failed to replace cached template: %w", err)
+    }
+    if err := os.Rename(staging, target); err != nil {
+        return fmt.Errorf("failed to cache template: %w", err)
+    }
+
+    remotes, err := m.Remotes()
+    if err != nil {
+        return err
+    }
+
+    updated := []RemoteTemplate{{Name: name, URL: url, Ref: ref}}
+    for _, remote := range remotes {
+        if remote.Name != name {
+            updated = append(updated, remote)
+        }
+    }
+
+    delete(m.templates, name)
+    return m.saveRemotes(updated)
+}
+
+// UpdateRemote re-clones a git template at its registered ref.
+func (m *Manager) UpdateRemote(name string) error {
+    remote, err := m.Remote(name)
+    if err != nil {
+        return err
+    }
+
+    return m.AddRemote(remote.Name, remote.URL, remote.Ref)
+}
+
+// RemoveRemote unregisters a git template and deletes its cached copy.
+func (m *Manager) RemoveRemote(name string) error {
+    remotes, err := m.Remotes()
+    if err != nil {
+        return err
+    }
+
+    var kept []RemoteTemplate
+    for _, remote := range remotes {
+        if remote.Name != name {
+            kept = append(kept, remote)
+        }
+    }
+    if len(kept) == len(remotes) {
+        return fmt.Errorf("no git template named %s", name)
+    }
+
+    if err := os.RemoveAll(filepath.Join(m.dir, name)); err != nil {
+        return fmt.Errorf("failed to remove cached template: %w", err)
+    }
+
+    delete(m.templates, name)
+    return m.saveRemotes(kept)
+}
+
+func (m *Manager) saveRemotes(remotes []RemoteTemplate) error {
+    sort.Slice(remotes, func(i, j int) bool { return remotes[i].Name < remotes[j].Name })
+
+    data, err := yaml.Marshal(remotes)
+    if err != nil {
+        return fmt.Errorf("failed to encode template registry: %w", err)
+    }
+
+    if err := fsutil.WriteFileAtomic(filepath.Join(m.dir, registryFile), data, 0644); err != nil {
+        return fmt.Errorf("failed to write template registry: %w", err)
+    }
+
+    return nil
+}
+
+func (m *Manager) isRemote(name string) bool {
+    if m.dir == "" || !templateNamePattern.MatchString(name) {
+        return false
+    }
+
+    _, err := os.Stat(filepath.Join(m.dir, name, "template.yaml"))
+    return err == nil
+}
@@ -10,4 +10,9 @@ type Template struct {
     BuildArgs    map[string]string `yaml:"build_args"`
     DevCommand   []string          `yaml:"dev_command"`
     ProdCommand  []string          `yaml:"prod_command"`
+    DevImage     string            `yaml:"dev_image"`
+    Install      string            `yaml:"install_command"`
+    BuildCommand []string          `yaml:"build_command"`
+    StaticDir    string            `yaml:"static_dir"`
+    Ignore       []string          `yaml:"ignore"`
 }